@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"log"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,6 +11,7 @@ import (
 	"github.com/catalyst/backend/internal/api"
 	"github.com/catalyst/backend/internal/config"
 	"github.com/catalyst/backend/internal/database"
+	"github.com/catalyst/backend/internal/logstream"
 	"github.com/catalyst/backend/internal/websocket"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -52,6 +52,15 @@ func main() {
 	// Initialize WebSocket manager
 	wsManager := websocket.NewManager()
 
+	// Agent work runs in the separate cmd/worker binary (see its main.go),
+	// not in this process. Relay bridges the two: a cmd/worker replica has
+	// no WebSocket connections of its own, so it persists log lines to
+	// Mongo and this relay tails them back out to whichever of this API
+	// process's clients is subscribed to that task.
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	go logstream.RunRelay(relayCtx, db, wsManager)
+
 	// Setup Gin router
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -83,7 +92,7 @@ func main() {
 	// WebSocket endpoint
 	router.GET("/ws/:taskId", func(c *gin.Context) {
 		taskID := c.Param("taskId")
-		wsManager.HandleWebSocket(c.Writer, c.Request, taskID)
+		wsManager.HandleWebSocket(c.Writer, c.Request, db, taskID)
 	})
 
 	// Start server
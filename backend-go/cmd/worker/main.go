@@ -0,0 +1,96 @@
+// cmd/worker runs the agent pipeline execution side of the platform: it
+// claims deliveries off the durable job queue (see internal/queue) and runs
+// their orchestrator phases, without ever listening on HTTP or holding a
+// browser's WebSocket connection. cmd/server is the other half — the Gin
+// API and WebSocket hub that enqueues work and streams it back out. The two
+// binaries scale independently and are wired together only through Mongo:
+// the deliveries collection for work, and control_events/agent_logs (see
+// internal/bus and internal/logstream) for the control frames and log
+// lines that used to cross in-process between them.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/catalyst/backend/internal/bus"
+	"github.com/catalyst/backend/internal/config"
+	"github.com/catalyst/backend/internal/database"
+	"github.com/catalyst/backend/internal/queue"
+	"github.com/catalyst/backend/internal/websocket"
+	"github.com/joho/godotenv"
+	logger "github.com/sirupsen/logrus"
+)
+
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(".env"); err != nil {
+		logger.Warn("No .env file found")
+	}
+
+	// Initialize logger
+	logger.SetFormatter(&logger.JSONFormatter{})
+	logger.SetOutput(os.Stdout)
+	logLevel := os.Getenv("LOG_LEVEL")
+	if logLevel == "DEBUG" {
+		logger.SetLevel(logger.DebugLevel)
+	} else {
+		logger.SetLevel(logger.InfoLevel)
+	}
+
+	logger.Info("Starting Catalyst Worker (Go)...")
+
+	// Load configuration
+	cfg := config.LoadConfig()
+
+	// Connect to MongoDB
+	db, err := database.Connect(cfg.MongoURL, cfg.DBName)
+	if err != nil {
+		logger.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer db.Disconnect()
+
+	logger.Info("Connected to MongoDB")
+
+	// wsManager here never serves a real WebSocket connection — this
+	// process has no HTTP server — but agents still log through it (see
+	// logstream.Log), so it's passed down purely as the Mongo-writing half
+	// of that path. The cmd/server process is what actually fans logged
+	// lines back out to browsers, via logstream.RunRelay.
+	wsManager := websocket.NewManager()
+
+	// controlBus is where a running task's cancel/pause/resume lands
+	// locally; bus.Relay feeds it from the control_events collection that
+	// cmd/server's WebSocket handler durably publishes to, since that
+	// handler runs in a different OS process from whichever worker below
+	// is actually running the task.
+	controlBus := bus.NewBus()
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	go bus.Relay(ctx, db, controlBus)
+
+	// Run the worker pool that claims and runs queued tasks. Replicas of
+	// this binary can run side by side; each worker claims deliveries
+	// independently via a leased FindOneAndUpdate.
+	for i := 0; i < cfg.WorkerCount; i++ {
+		w := queue.NewWorker(db, wsManager, cfg, controlBus)
+		go w.Run(ctx)
+	}
+	logger.Infof("Started %d worker(s)", cfg.WorkerCount)
+
+	// Sweep for tasks abandoned by a crashed worker that the normal Claim()
+	// path can no longer reach.
+	go queue.RunReaper(ctx, db)
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("Shutting down worker...")
+	stop()
+	logger.Info("Worker exited")
+}
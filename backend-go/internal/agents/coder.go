@@ -2,14 +2,15 @@ package agents
 
 import (
 	"context"
-	"time"
+	"io"
+	"strings"
 
 	"github.com/catalyst/backend/internal/config"
 	"github.com/catalyst/backend/internal/database"
 	"github.com/catalyst/backend/internal/llm"
-	"github.com/catalyst/backend/internal/models"
+	"github.com/catalyst/backend/internal/logstream"
 	"github.com/catalyst/backend/internal/websocket"
-	"github.com/google/uuid"
+	logger "github.com/sirupsen/logrus"
 )
 
 type CoderAgent struct {
@@ -26,7 +27,7 @@ func NewCoderAgent(db *database.Database, wsManager *websocket.Manager, cfg *con
 	}
 }
 
-func (a *CoderAgent) Code(taskID, architecture, feedback string) (string, error) {
+func (a *CoderAgent) Code(ctx context.Context, taskID, architecture, feedback string) (string, error) {
 	if feedback != "" {
 		a.log(taskID, "Coder", "🔄 Fixing code based on feedback...")
 	} else {
@@ -40,33 +41,40 @@ func (a *CoderAgent) Code(taskID, architecture, feedback string) (string, error)
 	}
 	userPrompt += "\n\nGenerate complete code implementation. Provide file paths and code content."
 
-	response, err := a.llmClient.SendMessage(systemPrompt, userPrompt)
+	fields := logger.Fields{"agent": "Coder", "task_id": taskID, "phase": "coding"}
+	logger.WithFields(fields).Info("streaming code generation")
+
+	// streamCtx is cancelled once this call returns (even on the happy
+	// path), so the streamClaude goroutine's blocked send to deltas wakes
+	// up via its ctx.Done() case instead of leaking forever whenever
+	// LimitReader's cap stops io.Copy from reading the rest of the stream.
+	streamCtx, cancelStream := context.WithCancel(ctx)
+	defer cancelStream()
+
+	deltas, err := a.llmClient.StreamMessage(streamCtx, systemPrompt, userPrompt)
 	if err != nil {
 		a.log(taskID, "Coder", "❌ Coding failed: "+err.Error())
+		logger.WithFields(fields).WithError(err).Error("code generation failed")
+		return "", err
+	}
+
+	lw := logstream.NewLineWriter(a.db, a.wsManager, taskID, "Coder")
+	defer lw.Close()
+
+	var response strings.Builder
+	reader := logstream.NewDeltaReader(deltas)
+	if _, err := io.Copy(io.MultiWriter(&response, lw), io.LimitReader(reader, logstream.MaxStreamBytes)); err != nil {
+		a.log(taskID, "Coder", "❌ Coding failed: "+err.Error())
+		logger.WithFields(fields).WithError(err).Error("code generation failed")
 		return "", err
 	}
 
 	a.log(taskID, "Coder", "✅ Code generated successfully")
-	return response, nil
+	_, tokensOut := reader.Tokens()
+	logger.WithFields(logger.Fields{"agent": "Coder", "task_id": taskID, "phase": "coding", "tokens": tokensOut}).Info("code generation complete")
+	return response.String(), nil
 }
 
 func (a *CoderAgent) log(taskID, agentName, message string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	log := models.AgentLog{
-		ID:        uuid.New().String(),
-		TaskID:    taskID,
-		AgentName: agentName,
-		Message:   message,
-		Timestamp: time.Now(),
-	}
-
-	a.db.Logs.InsertOne(ctx, log)
-	a.wsManager.SendLog(taskID, map[string]interface{}{
-		"task_id":    taskID,
-		"agent_name": agentName,
-		"message":    message,
-		"timestamp":  log.Timestamp.Format(time.RFC3339),
-	})
+	logstream.Log(a.db, a.wsManager, taskID, agentName, message)
 }
\ No newline at end of file
@@ -9,15 +9,20 @@ import (
 
 	"github.com/catalyst/backend/internal/config"
 	"github.com/catalyst/backend/internal/database"
+	"github.com/catalyst/backend/internal/deploy"
+	"github.com/catalyst/backend/internal/logstream"
 	"github.com/catalyst/backend/internal/models"
 	"github.com/catalyst/backend/internal/websocket"
 	"github.com/google/uuid"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type DeployerAgent struct {
 	db        *database.Database
 	wsManager *websocket.Manager
 	cfg       *config.Config
+	pipeline  *deploy.Pipeline
 }
 
 func NewDeployerAgent(db *database.Database, wsManager *websocket.Manager, cfg *config.Config) *DeployerAgent {
@@ -25,63 +30,185 @@ func NewDeployerAgent(db *database.Database, wsManager *websocket.Manager, cfg *
 		db:        db,
 		wsManager: wsManager,
 		cfg:       cfg,
+		pipeline:  deploy.NewPipeline(cfg),
 	}
 }
 
-func (a *DeployerAgent) Deploy(taskID, code, projectID string) (string, string, error) {
+// Deploy builds code and ships it through a deploy.Target chosen per
+// project (Project.DeployTarget, falling back to cfg.DefaultDeployTarget),
+// modeled on a CI runner: materialize, build, push to a registry, apply.
+// A project that set a Project.DeploymentPolicy instead fans the same
+// build out across every target that policy lists, via deployMulti.
+func (a *DeployerAgent) Deploy(ctx context.Context, taskID, code, projectID string) (string, string, error) {
+	if policy := a.deploymentPolicy(ctx, projectID); policy != nil && len(policy.Targets) > 0 {
+		return a.deployMulti(ctx, taskID, code, *policy)
+	}
+
 	a.log(taskID, "Deployer", "🚀 Starting deployment process...")
 
-	// Generate commit SHA
-	hash := sha256.Sum256([]byte(code))
-	commitSHA := hex.EncodeToString(hash[:])[:12]
+	fields := logger.Fields{"agent": "Deployer", "task_id": taskID, "phase": "deploy"}
+	logger.WithFields(fields).Info("starting deployment")
 
-	// Generate deployment URL
-	deploymentURL := fmt.Sprintf("https://catalyst-%s.deploy.catalyst.ai", projectID[:8])
+	target, err := deploy.New(a.targetName(ctx, projectID), a.cfg)
+	if err != nil {
+		a.log(taskID, "Deployer", "❌ Deployment failed: "+err.Error())
+		logger.WithFields(fields).WithError(err).Error("deployment failed")
+		return "", "", err
+	}
 
-	a.log(taskID, "Deployer", "📦 Building application...")
-	time.Sleep(1 * time.Second)
+	a.log(taskID, "Deployer", fmt.Sprintf("📦 Building application for %s...", target.Name()))
 
-	a.log(taskID, "Deployer", "☁️ Deploying to cloud...")
-	time.Sleep(1 * time.Second)
+	lw := logstream.NewLineWriter(a.db, a.wsManager, taskID, "Deployer")
+	defer lw.Close()
 
-	// Create deployment record
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	result, duration, err := a.pipeline.Run(ctx, taskID, code, target, lw)
+	if err != nil {
+		a.log(taskID, "Deployer", "❌ Deployment failed: "+err.Error())
+		logger.WithFields(fields).WithError(err).Error("deployment failed")
+		return "", "", err
+	}
+
+	hash := sha256.Sum256([]byte(code))
+	commitSHA := hex.EncodeToString(hash[:])[:12]
+	cost := deploy.Cost(duration)
+
+	dbCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	deployment := models.Deployment{
 		ID:        uuid.New().String(),
 		TaskID:    taskID,
-		URL:       deploymentURL,
+		URL:       result.URL,
 		CommitSHA: commitSHA,
-		Cost:      0.25,
-		Report:    fmt.Sprintf("Deployment successful\nURL: %s\nCommit: %s\nStatus: Live", deploymentURL, commitSHA),
+		Cost:      cost,
+		Report:    fmt.Sprintf("Deployment successful\nTarget: %s\nURL: %s\nCommit: %s\nStatus: Live", target.Name(), result.URL, commitSHA),
 		CreatedAt: time.Now(),
 	}
 
-	a.db.Deploys.InsertOne(ctx, deployment)
+	a.db.Deploys.InsertOne(dbCtx, deployment)
 
-	a.log(taskID, "Deployer", fmt.Sprintf("✅ Deployment successful: %s", deploymentURL))
+	a.log(taskID, "Deployer", fmt.Sprintf("✅ Deployment successful: %s", result.URL))
+	logger.WithFields(fields).Info("deployment complete")
 
-	return deploymentURL, commitSHA, nil
+	return result.URL, commitSHA, nil
 }
 
-func (a *DeployerAgent) log(taskID, agentName, message string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// targetName reads the project's configured deploy target, falling back
+// to cfg.DefaultDeployTarget if the project has none set or can't be
+// loaded.
+func (a *DeployerAgent) targetName(ctx context.Context, projectID string) string {
+	dbCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	log := models.AgentLog{
+	var project models.Project
+	if err := a.db.Projects.FindOne(dbCtx, bson.M{"id": projectID}).Decode(&project); err != nil {
+		return a.cfg.DefaultDeployTarget
+	}
+	return project.DeployTarget
+}
+
+// deploymentPolicy reads the project's DeploymentPolicy, if it set one. A
+// nil return means the project uses the single deploy.Target targetName
+// resolves instead.
+func (a *DeployerAgent) deploymentPolicy(ctx context.Context, projectID string) *models.DeploymentPolicy {
+	dbCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var project models.Project
+	if err := a.db.Projects.FindOne(dbCtx, bson.M{"id": projectID}).Decode(&project); err != nil {
+		return nil
+	}
+	return project.DeploymentPolicy
+}
+
+// deployMulti fans code out across policy's targets via deploy.Replicator,
+// persisting one models.DeploymentExecution per target so the UI can show
+// per-environment status, plus a single models.Deployment pointing at the
+// first target to succeed — mirroring Deploy's (url, commitSHA, error)
+// shape so the pipeline's deployer node doesn't need its own multi-target
+// branch.
+func (a *DeployerAgent) deployMulti(ctx context.Context, taskID, code string, policy models.DeploymentPolicy) (string, string, error) {
+	a.log(taskID, "Deployer", fmt.Sprintf("🚀 Starting multi-target deployment across %d target(s)...", len(policy.Targets)))
+
+	fields := logger.Fields{"agent": "Deployer", "task_id": taskID, "phase": "deploy_multi"}
+	logger.WithFields(fields).Info("starting multi-target deployment")
+
+	lw := logstream.NewLineWriter(a.db, a.wsManager, taskID, "Deployer")
+	defer lw.Close()
+
+	replicator := deploy.NewReplicator(a.cfg)
+	outcomes, err := replicator.Run(ctx, taskID, code, toDeployPolicy(policy), lw)
+	if err != nil {
+		a.log(taskID, "Deployer", "❌ Multi-target deployment failed: "+err.Error())
+		logger.WithFields(fields).WithError(err).Error("multi-target deployment failed")
+		return "", "", err
+	}
+
+	dbCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var primaryURL string
+	var totalDuration time.Duration
+	for _, outcome := range outcomes {
+		totalDuration += outcome.Duration
+
+		exec := models.DeploymentExecution{
+			ID:         uuid.New().String(),
+			TaskID:     taskID,
+			Target:     outcome.Target,
+			StartedAt:  time.Now().Add(-outcome.Duration),
+			FinishedAt: time.Now(),
+			Cost:       deploy.Cost(outcome.Duration),
+		}
+		if outcome.Err != nil {
+			exec.Status = "failed"
+			exec.Error = outcome.Err.Error()
+			a.log(taskID, "Deployer", fmt.Sprintf("❌ %s failed: %v", outcome.Target, outcome.Err))
+		} else {
+			exec.Status = "success"
+			if primaryURL == "" {
+				primaryURL = outcome.Result.URL
+			}
+			a.log(taskID, "Deployer", fmt.Sprintf("✅ %s live: %s", outcome.Target, outcome.Result.URL))
+		}
+		a.db.DeployExecutions.InsertOne(dbCtx, exec)
+	}
+
+	if primaryURL == "" {
+		err := fmt.Errorf("deploy: every target in policy failed")
+		logger.WithFields(fields).WithError(err).Error("multi-target deployment failed")
+		return "", "", err
+	}
+
+	hash := sha256.Sum256([]byte(code))
+	commitSHA := hex.EncodeToString(hash[:])[:12]
+
+	a.db.Deploys.InsertOne(dbCtx, models.Deployment{
 		ID:        uuid.New().String(),
 		TaskID:    taskID,
-		AgentName: agentName,
-		Message:   message,
-		Timestamp: time.Now(),
+		URL:       primaryURL,
+		CommitSHA: commitSHA,
+		Cost:      deploy.Cost(totalDuration),
+		Report:    fmt.Sprintf("Multi-target deployment across %d target(s); see deployment_executions for per-target status.", len(policy.Targets)),
+		CreatedAt: time.Now(),
+	})
+
+	a.log(taskID, "Deployer", fmt.Sprintf("✅ Deployment successful: %s", primaryURL))
+	logger.WithFields(fields).Info("multi-target deployment complete")
+
+	return primaryURL, commitSHA, nil
+}
+
+// toDeployPolicy converts the wire/storage form of a DeploymentPolicy into
+// the plain form deploy.Replicator executes.
+func toDeployPolicy(p models.DeploymentPolicy) deploy.DeploymentPolicy {
+	targets := make([]deploy.TargetConfig, len(p.Targets))
+	for i, t := range p.Targets {
+		targets[i] = deploy.TargetConfig{Scheme: t.Scheme, Config: t.Config}
 	}
+	return deploy.DeploymentPolicy{Targets: targets, Atomic: p.Atomic}
+}
 
-	a.db.Logs.InsertOne(ctx, log)
-	a.wsManager.SendLog(taskID, map[string]interface{}{
-		"task_id":    taskID,
-		"agent_name": agentName,
-		"message":    message,
-		"timestamp":  log.Timestamp.Format(time.RFC3339),
-	})
-}
\ No newline at end of file
+func (a *DeployerAgent) log(taskID, agentName, message string) {
+	logstream.Log(a.db, a.wsManager, taskID, agentName, message)
+}
@@ -2,20 +2,27 @@ package agents
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/catalyst/backend/internal/config"
 	"github.com/catalyst/backend/internal/database"
 	"github.com/catalyst/backend/internal/llm"
+	"github.com/catalyst/backend/internal/logstream"
 	"github.com/catalyst/backend/internal/models"
+	"github.com/catalyst/backend/internal/sources"
 	"github.com/catalyst/backend/internal/websocket"
 	"github.com/google/uuid"
+	logger "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
 )
 
 type ExplorerAgent struct {
 	db        *database.Database
 	wsManager *websocket.Manager
 	llmClient *llm.Client
+	cfg       *config.Config
 }
 
 func NewExplorerAgent(db *database.Database, wsManager *websocket.Manager, cfg *config.Config) *ExplorerAgent {
@@ -23,48 +30,56 @@ func NewExplorerAgent(db *database.Database, wsManager *websocket.Manager, cfg *
 		db:        db,
 		wsManager: wsManager,
 		llmClient: llm.NewClient(cfg.LLMKey, cfg.LLMProvider, cfg.LLMModel),
+		cfg:       cfg,
 	}
 }
 
-func (a *ExplorerAgent) ScanSystem(systemName, repoURL, jiraProject string) error {
+// ScanSystem fans out across every source.SourceProvider this scan has
+// enough configuration for — Git if repoURL is set, Jira if jiraProject is
+// set and cfg has a Jira base URL, Confluence if confluenceSpace is set and
+// cfg has a Confluence base URL (their tokens are not checked) — and feeds
+// their combined SystemArtifacts
+// to the LLM for the brief/risks/proposals synthesis.
+func (a *ExplorerAgent) ScanSystem(systemName, repoURL, jiraProject, confluenceSpace string) error {
 	scanID := uuid.New().String()
 
 	a.log(scanID, "Explorer", "🔍 Scanning system: "+systemName)
 
-	// Gather system context (mocked connectors)
-	context := "System: " + systemName + "\n"
+	providers := a.providersFor(repoURL, jiraProject, confluenceSpace)
+	artifacts := a.fetchAll(scanID, providers)
 
-	if repoURL != "" {
-		a.log(scanID, "Explorer", "📂 Analyzing repository...")
-		context += "Repository: " + repoURL + " (mocked analysis)\n"
+	sysContext := "System: " + systemName + "\n\n"
+	refs := make([]string, 0, len(artifacts))
+	for _, art := range artifacts {
+		sysContext += fmt.Sprintf("--- [%s] %s ---\n%s\n\n", art.Source, art.Title, art.Content)
+		refs = append(refs, art.Source+":"+art.Ref)
 	}
-
-	if jiraProject != "" {
-		a.log(scanID, "Explorer", "📋 Analyzing Jira project...")
-		context += "Jira: " + jiraProject + " (mocked analysis)\n"
+	if len(artifacts) == 0 {
+		sysContext += "(no source providers configured or reachable for this scan)\n"
 	}
 
-	// AI analysis
-	systemPrompt := "You are an enterprise explorer agent. Analyze existing systems read-only and provide insights, risks, and enhancement proposals. Never modify production systems."
-	userPrompt := context + "\n\nProvide: 1) System brief, 2) Risk assessment, 3) Enhancement proposals. Be enterprise-safe."
+	systemPrompt := "You are an enterprise explorer agent. Analyze existing systems read-only and provide insights, risks, and enhancement proposals. Never modify production systems. Respond with a single JSON object: {\"brief\": string, \"risks\": [string], \"proposals\": [string]}. No prose outside the JSON."
+	userPrompt := sysContext + "\n\nProvide: 1) System brief, 2) Risk assessment, 3) Enhancement proposals. Be enterprise-safe."
 
-	response, err := a.llmClient.SendMessage(systemPrompt, userPrompt)
+	response, err := a.llmClient.SendMessage(context.Background(), systemPrompt, userPrompt)
 	if err != nil {
 		a.log(scanID, "Explorer", "❌ Scan failed: "+err.Error())
 		return err
 	}
 
-	// Create scan record
+	brief, risks, proposals := parseScanResponse(response)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	scan := models.ExplorerScan{
-		ID:         scanID,
-		SystemName: systemName,
-		Brief:      response[:min(len(response), 500)],
-		Risks:      []string{"Data exposure risk", "Legacy dependencies"},
-		Proposals:  []string{"API modernization", "Add monitoring"},
-		CreatedAt:  time.Now(),
+		ID:           scanID,
+		SystemName:   systemName,
+		Brief:        brief,
+		Risks:        risks,
+		Proposals:    proposals,
+		ArtifactRefs: refs,
+		CreatedAt:    time.Now(),
 	}
 
 	a.db.Scans.InsertOne(ctx, scan)
@@ -73,23 +88,84 @@ func (a *ExplorerAgent) ScanSystem(systemName, repoURL, jiraProject string) erro
 	return nil
 }
 
-func (a *ExplorerAgent) log(scanID, agentName, message string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	log := models.AgentLog{
-		ID:        uuid.New().String(),
-		TaskID:    scanID,
-		AgentName: agentName,
-		Message:   message,
-		Timestamp: time.Now(),
+// parseScanResponse pulls brief/risks/proposals out of the LLM's JSON
+// envelope. The model doesn't always comply with the requested shape, so a
+// response that isn't a JSON object with a "brief" field falls back to the
+// raw text as the brief with empty risks/proposals, rather than erroring
+// the whole scan out.
+func parseScanResponse(response string) (brief string, risks, proposals []string) {
+	parsed := gjson.Parse(response)
+	if !parsed.Get("brief").Exists() {
+		return response[:min(len(response), 500)], nil, nil
 	}
 
-	a.db.Logs.InsertOne(ctx, log)
-	a.wsManager.SendLog(scanID, map[string]interface{}{
-		"task_id":    scanID,
-		"agent_name": agentName,
-		"message":    message,
-		"timestamp":  log.Timestamp.Format(time.RFC3339),
+	brief = parsed.Get("brief").String()
+	parsed.Get("risks").ForEach(func(_, v gjson.Result) bool {
+		risks = append(risks, v.String())
+		return true
+	})
+	parsed.Get("proposals").ForEach(func(_, v gjson.Result) bool {
+		proposals = append(proposals, v.String())
+		return true
 	})
-}
\ No newline at end of file
+	return brief, risks, proposals
+}
+
+// providersFor builds the set of sources.SourceProvider this scan has
+// enough information to run; a provider whose inputs weren't supplied is
+// simply omitted rather than run against empty/default configuration.
+func (a *ExplorerAgent) providersFor(repoURL, jiraProject, confluenceSpace string) []sources.SourceProvider {
+	var providers []sources.SourceProvider
+
+	if repoURL != "" {
+		providers = append(providers, sources.NewGitProvider(repoURL, a.cfg.GitPAT))
+	}
+	if jiraProject != "" && a.cfg.JiraBaseURL != "" {
+		providers = append(providers, sources.NewJiraProvider(a.cfg.JiraBaseURL, jiraProject, a.cfg.JiraToken))
+	}
+	if confluenceSpace != "" && a.cfg.ConfluenceBaseURL != "" {
+		providers = append(providers, sources.NewConfluenceProvider(a.cfg.ConfluenceBaseURL, confluenceSpace, a.cfg.ConfluenceToken))
+	}
+
+	return providers
+}
+
+// fetchAll runs every provider concurrently and logs (rather than aborts
+// the scan on) a provider that fails, so one unreachable connector doesn't
+// blank out artifacts the others already gathered.
+func (a *ExplorerAgent) fetchAll(scanID string, providers []sources.SourceProvider) []sources.SystemArtifact {
+	var (
+		mu        sync.Mutex
+		artifacts []sources.SystemArtifact
+		wg        sync.WaitGroup
+	)
+
+	for _, p := range providers {
+		wg.Add(1)
+		go func(p sources.SourceProvider) {
+			defer wg.Done()
+
+			a.log(scanID, "Explorer", fmt.Sprintf("📂 Fetching from %s...", p.Name()))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			found, err := p.Fetch(ctx)
+			if err != nil {
+				logger.WithFields(logger.Fields{"provider": p.Name(), "scan_id": scanID}).WithError(err).Error("source provider fetch failed")
+				a.log(scanID, "Explorer", fmt.Sprintf("⚠️ %s fetch failed: %s", p.Name(), err.Error()))
+			}
+
+			mu.Lock()
+			artifacts = append(artifacts, found...)
+			mu.Unlock()
+		}(p)
+	}
+	wg.Wait()
+
+	return artifacts
+}
+
+func (a *ExplorerAgent) log(scanID, agentName, message string) {
+	logstream.Log(a.db, a.wsManager, scanID, agentName, message)
+}
@@ -2,14 +2,13 @@ package agents
 
 import (
 	"context"
-	"time"
 
 	"github.com/catalyst/backend/internal/config"
 	"github.com/catalyst/backend/internal/database"
 	"github.com/catalyst/backend/internal/llm"
-	"github.com/catalyst/backend/internal/models"
+	"github.com/catalyst/backend/internal/logstream"
 	"github.com/catalyst/backend/internal/websocket"
-	"github.com/google/uuid"
+	logger "github.com/sirupsen/logrus"
 )
 
 type ReviewerAgent struct {
@@ -26,39 +25,27 @@ func NewReviewerAgent(db *database.Database, wsManager *websocket.Manager, cfg *
 	}
 }
 
-func (a *ReviewerAgent) Review(taskID, code, testResult string) (string, error) {
+func (a *ReviewerAgent) Review(ctx context.Context, taskID, code, testResult string) (string, error) {
 	a.log(taskID, "Reviewer", "👀 Reviewing code quality and best practices...")
 
 	systemPrompt := "You are a code reviewer. Review code quality, architecture decisions, security, performance, and maintainability. Provide constructive feedback."
 	userPrompt := "Review this code: " + code[:min(len(code), 2000)] + "\n\nTest results: " + testResult[:min(len(testResult), 500)] + "\n\nProvide: quality score, recommendations, approval status."
 
-	response, err := a.llmClient.SendMessage(systemPrompt, userPrompt)
+	fields := logger.Fields{"agent": "Reviewer", "task_id": taskID, "phase": "review"}
+	logger.WithFields(fields).Info("requesting code review")
+
+	response, err := a.llmClient.SendMessage(ctx, systemPrompt, userPrompt)
 	if err != nil {
 		a.log(taskID, "Reviewer", "❌ Review failed: "+err.Error())
+		logger.WithFields(fields).WithError(err).Error("review failed")
 		return "", err
 	}
 
 	a.log(taskID, "Reviewer", "✅ Review completed: Approved for deployment")
+	logger.WithFields(fields).Info("review complete")
 	return response, nil
 }
 
 func (a *ReviewerAgent) log(taskID, agentName, message string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	log := models.AgentLog{
-		ID:        uuid.New().String(),
-		TaskID:    taskID,
-		AgentName: agentName,
-		Message:   message,
-		Timestamp: time.Now(),
-	}
-
-	a.db.Logs.InsertOne(ctx, log)
-	a.wsManager.SendLog(taskID, map[string]interface{}{
-		"task_id":    taskID,
-		"agent_name": agentName,
-		"message":    message,
-		"timestamp":  log.Timestamp.Format(time.RFC3339),
-	})
+	logstream.Log(a.db, a.wsManager, taskID, agentName, message)
 }
\ No newline at end of file
@@ -2,21 +2,23 @@ package agents
 
 import (
 	"context"
-	"math/rand"
-	"time"
+	"io"
+	"strings"
 
 	"github.com/catalyst/backend/internal/config"
 	"github.com/catalyst/backend/internal/database"
 	"github.com/catalyst/backend/internal/llm"
-	"github.com/catalyst/backend/internal/models"
+	"github.com/catalyst/backend/internal/logstream"
+	"github.com/catalyst/backend/internal/sandbox"
 	"github.com/catalyst/backend/internal/websocket"
-	"github.com/google/uuid"
+	logger "github.com/sirupsen/logrus"
 )
 
 type TesterAgent struct {
 	db        *database.Database
 	wsManager *websocket.Manager
 	llmClient *llm.Client
+	runner    *sandbox.Runner
 }
 
 func NewTesterAgent(db *database.Database, wsManager *websocket.Manager, cfg *config.Config) *TesterAgent {
@@ -24,53 +26,70 @@ func NewTesterAgent(db *database.Database, wsManager *websocket.Manager, cfg *co
 		db:        db,
 		wsManager: wsManager,
 		llmClient: llm.NewClient(cfg.LLMKey, cfg.LLMProvider, cfg.LLMModel),
+		runner:    sandbox.NewRunner(cfg),
 	}
 }
 
-func (a *TesterAgent) Test(taskID, code string) (string, bool, error) {
+// Test asks the LLM for a narrative analysis of code (bugs, edge cases,
+// security issues) and separately runs its test suite for real in
+// a.runner, which is the ground truth the pipeline retries on — the
+// narrative is carried along only as context for ReviewerAgent.
+func (a *TesterAgent) Test(ctx context.Context, taskID, code string) (string, *sandbox.TestReport, error) {
 	a.log(taskID, "Tester", "🧪 Running tests and analyzing code...")
 
 	systemPrompt := "You are a testing agent. Analyze code and create comprehensive test scenarios. Identify bugs, edge cases, and potential issues."
-	code Prompt := "Analyze this code and provide test results: " + code[:min(len(code), 3000)] + "\n\nIdentify: bugs, edge cases, security issues. Output: {passed: bool, issues: [], suggestions: []}"
+	userPrompt := "Analyze this code and provide test results: " + code[:min(len(code), 3000)] + "\n\nIdentify: bugs, edge cases, security issues. Output: {passed: bool, issues: [], suggestions: []}"
 
-	response, err := a.llmClient.SendMessage(systemPrompt, userPrompt)
+	fields := logger.Fields{"agent": "Tester", "task_id": taskID, "phase": "testing"}
+	logger.WithFields(fields).Info("streaming test analysis")
+
+	// streamCtx is cancelled once this call returns (even on the happy
+	// path), so the streamClaude goroutine's blocked send to deltas wakes
+	// up via its ctx.Done() case instead of leaking forever whenever
+	// LimitReader's cap stops io.Copy from reading the rest of the stream.
+	streamCtx, cancelStream := context.WithCancel(ctx)
+	defer cancelStream()
+
+	deltas, err := a.llmClient.StreamMessage(streamCtx, systemPrompt, userPrompt)
 	if err != nil {
 		a.log(taskID, "Tester", "❌ Testing failed: "+err.Error())
-		return "", false, err
+		logger.WithFields(fields).WithError(err).Error("test analysis failed")
+		return "", nil, err
+	}
+
+	lw := logstream.NewLineWriter(a.db, a.wsManager, taskID, "Tester")
+	defer lw.Close()
+
+	var response strings.Builder
+	reader := logstream.NewDeltaReader(deltas)
+	if _, err := io.Copy(io.MultiWriter(&response, lw), io.LimitReader(reader, logstream.MaxStreamBytes)); err != nil {
+		a.log(taskID, "Tester", "❌ Testing failed: "+err.Error())
+		logger.WithFields(fields).WithError(err).Error("test analysis failed")
+		return "", nil, err
 	}
 
-	// Simulate test execution (66% pass rate)
-	rand.Seed(time.Now().UnixNano())
-	passed := rand.Float32() < 0.66
+	_, tokensOut := reader.Tokens()
+	logger.WithFields(logger.Fields{"agent": "Tester", "task_id": taskID, "phase": "testing", "tokens": tokensOut}).Info("test analysis complete")
 
-	if passed {
+	a.log(taskID, "Tester", "📦 Running test suite in sandbox...")
+	report, err := a.runner.Run(ctx, sandbox.ParseArtifact(code))
+	if err != nil {
+		a.log(taskID, "Tester", "❌ Sandbox execution failed: "+err.Error())
+		logger.WithFields(fields).WithError(err).Error("sandbox execution failed")
+		return "", nil, err
+	}
+
+	if report.Ok() {
 		a.log(taskID, "Tester", "✅ All tests passed")
 	} else {
 		a.log(taskID, "Tester", "⚠️ Tests found issues, routing back to coder...")
 	}
 
-	return response, passed, nil
+	return response.String(), report, nil
 }
 
 func (a *TesterAgent) log(taskID, agentName, message string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	log := models.AgentLog{
-		ID:        uuid.New().String(),
-		TaskID:    taskID,
-		AgentName: agentName,
-		Message:   message,
-		Timestamp: time.Now(),
-	}
-
-	a.db.Logs.InsertOne(ctx, log)
-	a.wsManager.SendLog(taskID, map[string]interface{}{
-		"task_id":    taskID,
-		"agent_name": agentName,
-		"message":    message,
-		"timestamp":  log.Timestamp.Format(time.RFC3339),
-	})
+	logstream.Log(a.db, a.wsManager, taskID, agentName, message)
 }
 
 func min(a, b int) int {
@@ -9,7 +9,8 @@ import (
 	"github.com/catalyst/backend/internal/config"
 	"github.com/catalyst/backend/internal/database"
 	"github.com/catalyst/backend/internal/models"
-	"github.com/catalyst/backend/internal/orchestrator"
+	"github.com/catalyst/backend/internal/pipeline"
+	"github.com/catalyst/backend/internal/queue"
 	"github.com/catalyst/backend/internal/websocket"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -35,10 +36,14 @@ func SetupRoutes(router *gin.Engine, db *database.Database, wsManager *websocket
 
 	// Deployments
 	api.GET("/deployments/:taskId", getDeployment(db))
+	api.GET("/deployments/:taskId/executions", listDeploymentExecutions(db))
 
 	// Explorer
 	api.POST("/explorer/scan", createExplorerScan(db, wsManager, cfg))
 	api.GET("/explorer/scans", listExplorerScans(db))
+
+	// Pipelines
+	api.POST("/pipelines", createPipeline(db))
 }
 
 func createProject(db *database.Database) gin.HandlerFunc {
@@ -50,11 +55,13 @@ func createProject(db *database.Database) gin.HandlerFunc {
 		}
 
 		project := models.Project{
-			ID:          uuid.New().String(),
-			Name:        req.Name,
-			Description: req.Description,
-			Status:      "active",
-			CreatedAt:   time.Now(),
+			ID:               uuid.New().String(),
+			Name:             req.Name,
+			Description:      req.Description,
+			Status:           "active",
+			DeployTarget:     req.DeployTarget,
+			DeploymentPolicy: req.DeploymentPolicy,
+			CreatedAt:        time.Now(),
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -113,6 +120,8 @@ func getProject(db *database.Database) gin.HandlerFunc {
 }
 
 func createTask(db *database.Database, wsManager *websocket.Manager, cfg *config.Config) gin.HandlerFunc {
+	taskQueue := queue.NewQueue(db)
+
 	return func(c *gin.Context) {
 		var req models.TaskCreate
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -138,8 +147,13 @@ func createTask(db *database.Database, wsManager *websocket.Manager, cfg *config
 			return
 		}
 
-		// Start task execution in background
-		go orchestrator.ExecuteTask(db, wsManager, cfg, task.ID, task.Prompt, task.ProjectID)
+		// Hand the task off to the worker pool instead of running it
+		// in-process: any worker replica can pick it up, and a restart
+		// mid-run no longer loses the task.
+		if _, err := taskQueue.Enqueue(ctx, task.ID, "pipeline"); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
 
 		c.JSON(http.StatusOK, task)
 	}
@@ -241,6 +255,39 @@ func getDeployment(db *database.Database) gin.HandlerFunc {
 	}
 }
 
+// listDeploymentExecutions returns every models.DeploymentExecution a
+// DeploymentPolicy fan-out recorded for taskID, one per target, so a UI
+// can show per-environment status rather than only the primary
+// deployment's.
+func listDeploymentExecutions(db *database.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskID := c.Param("taskId")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		opts := options.Find().SetSort(bson.D{{"started_at", 1}})
+		cursor, err := db.DeployExecutions.Find(ctx, bson.M{"task_id": taskID}, opts)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		defer cursor.Close(ctx)
+
+		var executions []models.DeploymentExecution
+		if err := cursor.All(ctx, &executions); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if executions == nil {
+			executions = []models.DeploymentExecution{}
+		}
+
+		c.JSON(http.StatusOK, executions)
+	}
+}
+
 func createExplorerScan(db *database.Database, wsManager *websocket.Manager, cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req models.ExplorerScanCreate
@@ -252,7 +299,7 @@ func createExplorerScan(db *database.Database, wsManager *websocket.Manager, cfg
 		// Create scan in background
 		go func() {
 			explorer := agents.NewExplorerAgent(db, wsManager, cfg)
-			explorer.ScanSystem(req.SystemName, req.RepoURL, req.JiraProject)
+			explorer.ScanSystem(req.SystemName, req.RepoURL, req.JiraProject, req.ConfluenceSpace)
 		}()
 
 		// Return immediate response
@@ -293,4 +340,40 @@ func listExplorerScans(db *database.Database) gin.HandlerFunc {
 
 		c.JSON(http.StatusOK, scans)
 	}
+}
+
+func createPipeline(db *database.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.PipelineCreate
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		parsed, err := pipeline.Load([]byte(req.YAML))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		def := models.PipelineDefinition{
+			ID:        uuid.New().String(),
+			ProjectID: req.ProjectID,
+			Name:      parsed.Name,
+			YAML:      req.YAML,
+			CreatedAt: time.Now(),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// One custom pipeline per project; re-uploading replaces it.
+		opts := options.Replace().SetUpsert(true)
+		if _, err := db.Pipelines.ReplaceOne(ctx, bson.M{"project_id": req.ProjectID}, def, opts); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, def)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,70 @@
+// Package bus is a tiny in-process pub/sub keyed by task ID. It carries
+// control events (cancel/pause/resume) decoded from inbound WebSocket
+// frames (see websocket.Manager) to whichever worker is currently running
+// that task's pipeline (see queue.Worker), without either package depending
+// on the other. Since cmd/server and cmd/worker are now separate processes,
+// that hand-off alone isn't enough to cross between them — see relay.go for
+// the Mongo-backed bridge that makes a Publish on one process's Bus reach a
+// Subscribe on another's.
+package bus
+
+import "sync"
+
+// Event is a control message published for a task.
+type Event struct {
+	Action string // "cancel", "pause", or "resume"
+}
+
+// Bus fans events for a task out to every current subscriber.
+type Bus struct {
+	mu   sync.RWMutex
+	subs map[string][]chan Event
+}
+
+func NewBus() *Bus {
+	return &Bus{subs: make(map[string][]chan Event)}
+}
+
+// Subscribe registers a new listener for taskID and returns its channel and
+// an unsubscribe func the caller must run once it stops listening.
+func (b *Bus) Subscribe(taskID string) (<-chan Event, func()) {
+	ch := make(chan Event, 4)
+
+	b.mu.Lock()
+	b.subs[taskID] = append(b.subs[taskID], ch)
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		chans := b.subs[taskID]
+		for i, c := range chans {
+			if c == ch {
+				b.subs[taskID] = append(chans[:i], chans[i+1:]...)
+				break
+			}
+		}
+		if len(b.subs[taskID]) == 0 {
+			delete(b.subs, taskID)
+		}
+		close(ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish fans ev out to every current subscriber of taskID. A subscriber
+// with a full buffer has the event dropped rather than blocking the
+// publisher — control events are idempotent enough to retry from the client.
+func (b *Bus) Publish(taskID string, ev Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, ch := range b.subs[taskID] {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package bus
+
+import (
+	"context"
+	"time"
+
+	"github.com/catalyst/backend/internal/database"
+	"github.com/catalyst/backend/internal/models"
+	"github.com/google/uuid"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// relayPollInterval is how often Relay checks control_events for anything
+// new. A cmd/server and cmd/worker no longer share memory, so this is the
+// cross-process counterpart to Bus's in-process fan-out; 500ms keeps a
+// cancel/pause/resume reaching the owning worker close to instant without
+// hammering Mongo.
+const relayPollInterval = 500 * time.Millisecond
+
+// PublishRemote durably records ev in the control_events collection so
+// every process running Relay republishes it onto its own local Bus. Call
+// this (not Publish) from the API process's WebSocket handler, since the
+// queue.Worker actually running taskID's pipeline is very likely a
+// different OS process after the cmd/server / cmd/worker split.
+func PublishRemote(ctx context.Context, db *database.Database, taskID, action string) error {
+	event := models.ControlEvent{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		Action:    action,
+		CreatedAt: time.Now(),
+	}
+	_, err := db.ControlEvents.InsertOne(ctx, event)
+	return err
+}
+
+// Relay polls control_events for rows inserted since the last poll and
+// republishes each onto local exactly as Publish would, bridging
+// PublishRemote's durable hand-off back into the in-process Subscribe calls
+// queue.Worker already makes. Blocks until ctx is cancelled.
+func Relay(ctx context.Context, db *database.Database, local *Bus) {
+	since := time.Now()
+
+	ticker := time.NewTicker(relayPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			since = relayOnce(ctx, db, local, since)
+		}
+	}
+}
+
+func relayOnce(ctx context.Context, db *database.Database, local *Bus, since time.Time) time.Time {
+	cursor, err := db.ControlEvents.Find(ctx,
+		bson.M{"created_at": bson.M{"$gt": since}},
+		options.Find().SetSort(bson.D{{"created_at", 1}}),
+	)
+	if err != nil {
+		logger.Errorf("bus: failed to poll control events: %v", err)
+		return since
+	}
+	defer cursor.Close(ctx)
+
+	latest := since
+	var event models.ControlEvent
+	for cursor.Next(ctx) {
+		if err := cursor.Decode(&event); err != nil {
+			logger.Errorf("bus: failed to decode control event: %v", err)
+			continue
+		}
+		local.Publish(event.TaskID, Event{Action: event.Action})
+		if event.CreatedAt.After(latest) {
+			latest = event.CreatedAt
+		}
+	}
+	return latest
+}
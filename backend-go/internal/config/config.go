@@ -1,6 +1,10 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 type Config struct {
 	MongoURL    string
@@ -11,18 +15,79 @@ type Config struct {
 	LLMModel    string
 	Port        string
 	Environment string
+
+	// WorkerCount is how many queue.Worker goroutines cmd/worker runs in a
+	// single process; replicas of that binary can run side by side on top
+	// of that, since claims are leased rather than partitioned.
+	WorkerCount int
+
+	// SandboxDryRun forces TesterAgent's sandbox.Runner to simulate test
+	// results instead of executing them in Docker, even if Docker is
+	// available.
+	SandboxDryRun bool
+	// SandboxTimeout bounds how long a single sandboxed test run may take
+	// before it's killed.
+	SandboxTimeout time.Duration
+
+	// RegistryURL/User/Pass are the container registry DeployerAgent pushes
+	// built images to before applying a docker-compose or kubernetes
+	// deploy.Target.
+	RegistryURL  string
+	RegistryUser string
+	RegistryPass string
+	// DefaultDeployTarget is the deploy.Target used for a project that
+	// didn't set its own Project.DeployTarget.
+	DefaultDeployTarget string
+	// KubeNamespace is the namespace DeployerAgent's kubernetes target
+	// applies manifests into.
+	KubeNamespace string
+	// S3Bucket is the bucket DeployerAgent's static-s3 target syncs built
+	// static assets to.
+	S3Bucket string
+	// DeployTimeout bounds how long a single deploy (build + push + apply)
+	// may take before it's killed.
+	DeployTimeout time.Duration
+
+	// GitPAT authenticates ExplorerAgent's sources.GitProvider clones of
+	// private repositories (sent as the clone's HTTP Basic password).
+	GitPAT string
+	// JiraBaseURL/JiraToken point sources.JiraProvider at a Jira Cloud site
+	// and its REST v3 API token.
+	JiraBaseURL string
+	JiraToken   string
+	// ConfluenceBaseURL/ConfluenceToken point sources.ConfluenceProvider at
+	// a Confluence Cloud site and its REST API token.
+	ConfluenceBaseURL string
+	ConfluenceToken   string
 }
 
 func LoadConfig() *Config {
 	return &Config{
-		MongoURL:    getEnv("MONGO_URL", "mongodb://localhost:27017"),
-		DBName:      getEnv("DB_NAME", "catalyst_db"),
-		CORSOrigins: getEnv("CORS_ORIGINS", "*"),
-		LLMKey:      getEnv("EMERGENT_LLM_KEY", ""),
-		LLMProvider: getEnv("DEFAULT_LLM_PROVIDER", "anthropic"),
-		LLMModel:    getEnv("DEFAULT_LLM_MODEL", "claude-3-7-sonnet-20250219"),
-		Port:        getEnv("BACKEND_PORT", "8001"),
-		Environment: getEnv("ENVIRONMENT", "development"),
+		MongoURL:       getEnv("MONGO_URL", "mongodb://localhost:27017"),
+		DBName:         getEnv("DB_NAME", "catalyst_db"),
+		CORSOrigins:    getEnv("CORS_ORIGINS", "*"),
+		LLMKey:         getEnv("EMERGENT_LLM_KEY", ""),
+		LLMProvider:    getEnv("DEFAULT_LLM_PROVIDER", "anthropic"),
+		LLMModel:       getEnv("DEFAULT_LLM_MODEL", "claude-3-7-sonnet-20250219"),
+		Port:           getEnv("BACKEND_PORT", "8001"),
+		Environment:    getEnv("ENVIRONMENT", "development"),
+		WorkerCount:    getEnvInt("WORKER_COUNT", 2),
+		SandboxDryRun:  getEnvBool("SANDBOX_DRY_RUN", false),
+		SandboxTimeout: getEnvDuration("SANDBOX_TIMEOUT", 2*time.Minute),
+
+		RegistryURL:         getEnv("REGISTRY_URL", ""),
+		RegistryUser:        getEnv("REGISTRY_USER", ""),
+		RegistryPass:        getEnv("REGISTRY_PASS", ""),
+		DefaultDeployTarget: getEnv("DEFAULT_DEPLOY_TARGET", "docker-compose"),
+		KubeNamespace:       getEnv("KUBE_NAMESPACE", "default"),
+		S3Bucket:            getEnv("S3_BUCKET", ""),
+		DeployTimeout:       getEnvDuration("DEPLOY_TIMEOUT", 5*time.Minute),
+
+		GitPAT:            getEnv("GIT_PAT", ""),
+		JiraBaseURL:       getEnv("JIRA_BASE_URL", ""),
+		JiraToken:         getEnv("JIRA_TOKEN", ""),
+		ConfluenceBaseURL: getEnv("CONFLUENCE_BASE_URL", ""),
+		ConfluenceToken:   getEnv("CONFLUENCE_TOKEN", ""),
 	}
 }
 
@@ -31,4 +96,25 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
@@ -9,13 +9,22 @@ import (
 )
 
 type Database struct {
-	Client   *mongo.Client
-	DB       *mongo.Database
-	Projects *mongo.Collection
-	Tasks    *mongo.Collection
-	Logs     *mongo.Collection
-	Deploys  *mongo.Collection
-	Scans    *mongo.Collection
+	Client     *mongo.Client
+	DB         *mongo.Database
+	Projects   *mongo.Collection
+	Tasks      *mongo.Collection
+	Logs       *mongo.Collection
+	Deploys    *mongo.Collection
+	Scans      *mongo.Collection
+	Deliveries *mongo.Collection
+	Pipelines  *mongo.Collection
+	// ControlEvents is how the API hands cancel/pause/resume control frames
+	// off to the separate cmd/worker process running a task's pipeline; see
+	// internal/bus.Relay.
+	ControlEvents *mongo.Collection
+	// DeployExecutions holds one models.DeploymentExecution per target a
+	// DeploymentPolicy fanned a build out to (see deploy.Replicator).
+	DeployExecutions *mongo.Collection
 }
 
 func Connect(mongoURL, dbName string) (*Database, error) {
@@ -35,13 +44,17 @@ func Connect(mongoURL, dbName string) (*Database, error) {
 	db := client.Database(dbName)
 
 	return &Database{
-		Client:   client,
-		DB:       db,
-		Projects: db.Collection("projects"),
-		Tasks:    db.Collection("tasks"),
-		Logs:     db.Collection("agent_logs"),
-		Deploys:  db.Collection("deployments"),
-		Scans:    db.Collection("explorer_scans"),
+		Client:           client,
+		DB:               db,
+		Projects:         db.Collection("projects"),
+		Tasks:            db.Collection("tasks"),
+		Logs:             db.Collection("agent_logs"),
+		Deploys:          db.Collection("deployments"),
+		Scans:            db.Collection("explorer_scans"),
+		Deliveries:       db.Collection("deliveries"),
+		Pipelines:        db.Collection("pipelines"),
+		ControlEvents:    db.Collection("control_events"),
+		DeployExecutions: db.Collection("deployment_executions"),
 	}, nil
 }
 
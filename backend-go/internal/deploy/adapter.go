@@ -0,0 +1,64 @@
+package deploy
+
+import (
+	"context"
+	"io"
+
+	"github.com/catalyst/backend/internal/config"
+)
+
+// Adapter ships a single already-built artifact to one deployment
+// environment as part of a DeploymentPolicy's fan-out, and can undo its
+// own side effects if a sibling adapter in the same policy fails and the
+// policy requested atomic semantics. Where Target owns build+push+apply as
+// one Deploy call, Adapter splits that into stages a Replicator can track
+// and roll back independently per target.
+type Adapter interface {
+	Name() string
+	// Prepare does whatever a target needs before the artifact is pushed,
+	// e.g. authenticating to a registry or retagging the image for this
+	// target's repository.
+	Prepare(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) error
+	// Push ships the prepared artifact and reports where it's reachable.
+	Push(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) (*Result, error)
+	// Verify confirms the pushed artifact is actually live.
+	Verify(ctx context.Context, taskID string, result *Result, out io.Writer) error
+	// Rollback undoes Push (and anything Prepare did) for a target that
+	// already succeeded before a sibling target in the same policy failed.
+	Rollback(ctx context.Context, taskID string, result *Result, out io.Writer) error
+}
+
+// TargetConfig names one Adapter a DeploymentPolicy runs (by registry
+// scheme, e.g. "ecr", "k8s") plus its per-target settings, layered over
+// config.Config's global defaults for that adapter.
+type TargetConfig struct {
+	Scheme string
+	Config map[string]string
+}
+
+// DeploymentPolicy is what Replicator executes: every target to fan a
+// single build out to, and whether a failed target should roll the others
+// back.
+type DeploymentPolicy struct {
+	Targets []TargetConfig
+	// Atomic rolls every already-succeeded target back if a later one in
+	// Targets fails, so the policy either lands everywhere or nowhere.
+	Atomic bool
+}
+
+// registry maps a TargetConfig's scheme to its Adapter constructor.
+var registry = map[string]func(map[string]string, *config.Config) Adapter{
+	"dockerhub": func(tc map[string]string, cfg *config.Config) Adapter { return NewDockerHubAdapter(tc, cfg) },
+	"ecr":       func(tc map[string]string, cfg *config.Config) Adapter { return NewECRAdapter(tc, cfg) },
+	"k8s":       func(tc map[string]string, cfg *config.Config) Adapter { return NewK8sAdapter(tc, cfg) },
+	"vercel":    func(tc map[string]string, cfg *config.Config) Adapter { return NewVercelAdapter(tc, cfg) },
+}
+
+// NewAdapter resolves a TargetConfig's scheme to an Adapter.
+func NewAdapter(scheme string, targetConfig map[string]string, cfg *config.Config) (Adapter, error) {
+	ctor, ok := registry[scheme]
+	if !ok {
+		return nil, errUnknownTarget(scheme)
+	}
+	return ctor(targetConfig, cfg), nil
+}
@@ -0,0 +1,84 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// recipe is the build command detected for a materialized workspace, plus
+// whether it produces a container image (so Pipeline knows whether to
+// build/push one) or a directory of static output (for the static-s3
+// target).
+type recipe struct {
+	cmd         []string
+	producesImg bool
+}
+
+// detectRecipe inspects a materialized workspace's top-level files to pick
+// a build command, preferring a Dockerfile when present since it's the
+// most explicit signal of how the project wants to be built.
+func detectRecipe(workspace string) (recipe, error) {
+	switch {
+	case exists(workspace, "Dockerfile"):
+		return recipe{cmd: []string{"docker", "build", "-t", "PLACEHOLDER", "."}, producesImg: true}, nil
+	case exists(workspace, "go.mod"):
+		return recipe{cmd: []string{"go", "build", "./..."}}, nil
+	case exists(workspace, "package.json"):
+		return recipe{cmd: []string{"npm", "run", "build"}}, nil
+	case exists(workspace, "requirements.txt"):
+		return recipe{cmd: []string{"python3", "-m", "compileall", "."}}, nil
+	default:
+		return recipe{}, fmt.Errorf("deploy: no recognizable build recipe in %s", workspace)
+	}
+}
+
+func exists(dir, name string) bool {
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
+
+// runStreamed runs name/args in workspace, piping combined stdout/stderr
+// into out (typically a logstream.LineWriter, so the build log reaches
+// Mongo and WebSocket subscribers line by line as it's produced) as it
+// runs rather than buffering the whole thing.
+func runStreamed(ctx context.Context, workspace string, out io.Writer, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = workspace
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
+
+// runStreamedEnv is runStreamed plus extra environment variables, for
+// commands that read a secret from the environment rather than stdin or
+// an argv flag (e.g. the Vercel CLI's VERCEL_TOKEN) — it must never be
+// passed as a command-line argument since that leaks it to any local user
+// via ps/proc, the same reasoning runPiped applies to stdin-fed secrets.
+func runStreamedEnv(ctx context.Context, workspace string, env []string, out io.Writer, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = workspace
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
+
+// runCaptured runs name/args in workspace (argv only, no shell) and returns
+// its trimmed stdout, for commands whose output feeds directly into a later
+// step — e.g. an ECR login token piped into `docker login` — rather than
+// being purely informational. Stderr still streams to out like runStreamed.
+func runCaptured(ctx context.Context, workspace string, out io.Writer, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = workspace
+	cmd.Stderr = out
+	stdout, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(stdout)), nil
+}
@@ -0,0 +1,66 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/catalyst/backend/internal/config"
+)
+
+var composeTmpl = template.Must(template.New("compose").Parse(`version: "3.8"
+services:
+  app:
+    image: {{.Image}}
+    ports:
+      - "{{.Port}}:8080"
+    restart: unless-stopped
+`))
+
+// ComposeTarget deploys by rendering a single-service docker-compose file
+// for the built image and bringing it up locally — the lightest-weight
+// target, suited to a single-node dev/staging host.
+type ComposeTarget struct{}
+
+func NewComposeTarget(cfg *config.Config) *ComposeTarget { return &ComposeTarget{} }
+
+func (t *ComposeTarget) Name() string { return "docker-compose" }
+
+func (t *ComposeTarget) Deploy(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) (*Result, error) {
+	port := portFor(taskID)
+
+	composePath := filepath.Join(workspace, "docker-compose.catalyst.yml")
+	f, err := os.Create(composePath)
+	if err != nil {
+		return nil, fmt.Errorf("render compose file: %w", err)
+	}
+	err = composeTmpl.Execute(f, struct {
+		Image string
+		Port  int
+	}{Image: imageRef, Port: port})
+	f.Close()
+	if err != nil {
+		return nil, fmt.Errorf("render compose file: %w", err)
+	}
+
+	if err := runStreamed(ctx, workspace, out, "docker", "compose", "-f", composePath, "up", "-d"); err != nil {
+		return nil, fmt.Errorf("docker compose up: %w", err)
+	}
+
+	return &Result{
+		URL: fmt.Sprintf("http://localhost:%d", port),
+		Ref: imageRef,
+	}, nil
+}
+
+// portFor derives a stable, spread-out host port from taskID so concurrent
+// deploys don't collide on the same compose host.
+func portFor(taskID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(taskID))
+	return 20000 + int(h.Sum32()%10000)
+}
@@ -0,0 +1,70 @@
+// Package deploy builds a CoderAgent artifact and ships it via a pluggable
+// Target (docker-compose, kubernetes, or static-s3), modeled on a CI
+// runner: materialize the code, run its build recipe, push the resulting
+// image to a registry, then apply it. It replaces DeployerAgent's old
+// sleep-and-fabricate-a-URL stub.
+//
+// Replicator is the multi-target counterpart to Pipeline/Target: instead
+// of applying one build to one environment, it fans the same build out
+// across every entry in a DeploymentPolicy via Adapter (Prepare/Push/
+// Verify/Rollback), rolling already-succeeded targets back if a later one
+// fails and the policy requested atomic semantics.
+package deploy
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/catalyst/backend/internal/config"
+)
+
+// Result is what a Target hands back once the artifact is live.
+type Result struct {
+	URL string
+	Ref string // image ref for docker-compose/kubernetes, object key prefix for static-s3
+}
+
+// Target applies a built artifact and reports where it's reachable. Build
+// and push are handled by Pipeline before Deploy is called; imageRef is
+// empty for targets (static-s3) that don't use a container image.
+type Target interface {
+	Name() string
+	Deploy(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) (*Result, error)
+}
+
+// costPerSecond turns a deploy's wall-clock duration into a Deployment.Cost
+// figure, in place of the old hard-coded 0.25 — a longer build/push/apply
+// genuinely costs more compute.
+const costPerSecond = 0.01
+
+// Cost converts a build+apply duration into the figure recorded on
+// models.Deployment.
+func Cost(d time.Duration) float64 {
+	return d.Seconds() * costPerSecond
+}
+
+// New resolves a project's deploy target name (Project.DeployTarget, or
+// cfg.DefaultDeployTarget if it didn't set one) to a Target.
+func New(name string, cfg *config.Config) (Target, error) {
+	if name == "" {
+		name = cfg.DefaultDeployTarget
+	}
+
+	switch name {
+	case "docker-compose":
+		return NewComposeTarget(cfg), nil
+	case "kubernetes":
+		return NewKubernetesTarget(cfg), nil
+	case "static-s3":
+		return NewS3Target(cfg), nil
+	default:
+		return nil, errUnknownTarget(name)
+	}
+}
+
+type errUnknownTarget string
+
+func (e errUnknownTarget) Error() string {
+	return "deploy: unknown target " + string(e)
+}
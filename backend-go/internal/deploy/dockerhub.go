@@ -0,0 +1,64 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/catalyst/backend/internal/config"
+)
+
+// DockerHubAdapter retags the build's image under a Docker Hub repository
+// and pushes it there, for a DeploymentPolicy target that wants the image
+// published publicly rather than applied to a cluster.
+type DockerHubAdapter struct {
+	repository string
+	user       string
+	pass       string
+}
+
+func NewDockerHubAdapter(targetConfig map[string]string, cfg *config.Config) *DockerHubAdapter {
+	return &DockerHubAdapter{
+		repository: targetConfig["repository"],
+		user:       cfg.RegistryUser,
+		pass:       cfg.RegistryPass,
+	}
+}
+
+func (a *DockerHubAdapter) Name() string { return "dockerhub" }
+
+func (a *DockerHubAdapter) tag(taskID string) string {
+	return fmt.Sprintf("docker.io/%s:%s", a.repository, shortID(taskID))
+}
+
+func (a *DockerHubAdapter) Prepare(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) error {
+	if err := runStreamed(ctx, workspace, out, "docker", "tag", imageRef, a.tag(taskID)); err != nil {
+		return fmt.Errorf("dockerhub: tag image: %w", err)
+	}
+	if a.user != "" {
+		if err := runPiped(ctx, workspace, a.pass, out, "docker", "login", "-u", a.user, "--password-stdin"); err != nil {
+			return fmt.Errorf("dockerhub: login: %w", err)
+		}
+	}
+	return nil
+}
+
+// Push ignores workspace — docker push addresses the image by tag alone.
+func (a *DockerHubAdapter) Push(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) (*Result, error) {
+	tag := a.tag(taskID)
+	if err := runStreamed(ctx, workspace, out, "docker", "push", tag); err != nil {
+		return nil, fmt.Errorf("dockerhub: push: %w", err)
+	}
+	return &Result{URL: fmt.Sprintf("https://hub.docker.com/r/%s", a.repository), Ref: tag}, nil
+}
+
+func (a *DockerHubAdapter) Verify(ctx context.Context, taskID string, result *Result, out io.Writer) error {
+	return runStreamed(ctx, "", out, "docker", "manifest", "inspect", result.Ref)
+}
+
+// Rollback removes the local tag. Docker Hub has no API to unpublish an
+// already-pushed tag, so this only stops this process from pushing it
+// again; deleting the remote tag is a human call.
+func (a *DockerHubAdapter) Rollback(ctx context.Context, taskID string, result *Result, out io.Writer) error {
+	return runStreamed(ctx, "", out, "docker", "rmi", result.Ref)
+}
@@ -0,0 +1,82 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/catalyst/backend/internal/config"
+)
+
+// ECRAdapter retags the build's image under an AWS ECR repository URI and
+// pushes it there, authenticating via the AWS CLI's ECR credential helper.
+type ECRAdapter struct {
+	repository string // e.g. "123456789012.dkr.ecr.us-east-1.amazonaws.com/catalyst"
+	region     string
+}
+
+func NewECRAdapter(targetConfig map[string]string, cfg *config.Config) *ECRAdapter {
+	return &ECRAdapter{
+		repository: targetConfig["repository"],
+		region:     targetConfig["region"],
+	}
+}
+
+func (a *ECRAdapter) Name() string { return "ecr" }
+
+func (a *ECRAdapter) tag(taskID string) string {
+	return fmt.Sprintf("%s:%s", a.repository, shortID(taskID))
+}
+
+// registryHost/repoName split ECR's combined "<registry>/<repo>" config
+// value, since login targets the former and the AWS CLI's image commands
+// address the latter.
+func (a *ECRAdapter) registryHost() string {
+	if i := strings.Index(a.repository, "/"); i >= 0 {
+		return a.repository[:i]
+	}
+	return a.repository
+}
+
+func (a *ECRAdapter) repoName() string {
+	if i := strings.Index(a.repository, "/"); i >= 0 {
+		return a.repository[i+1:]
+	}
+	return a.repository
+}
+
+func (a *ECRAdapter) Prepare(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) error {
+	token, err := runCaptured(ctx, workspace, out, "aws", "ecr", "get-login-password", "--region", a.region)
+	if err != nil {
+		return fmt.Errorf("ecr: get-login-password: %w", err)
+	}
+	if err := runPiped(ctx, workspace, token, out, "docker", "login", "--username", "AWS", "--password-stdin", a.registryHost()); err != nil {
+		return fmt.Errorf("ecr: login: %w", err)
+	}
+	if err := runStreamed(ctx, workspace, out, "docker", "tag", imageRef, a.tag(taskID)); err != nil {
+		return fmt.Errorf("ecr: tag image: %w", err)
+	}
+	return nil
+}
+
+// Push ignores workspace — docker push addresses the image by tag alone.
+func (a *ECRAdapter) Push(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) (*Result, error) {
+	tag := a.tag(taskID)
+	if err := runStreamed(ctx, workspace, out, "docker", "push", tag); err != nil {
+		return nil, fmt.Errorf("ecr: push: %w", err)
+	}
+	return &Result{URL: fmt.Sprintf("https://%s", a.repository), Ref: tag}, nil
+}
+
+func (a *ECRAdapter) Verify(ctx context.Context, taskID string, result *Result, out io.Writer) error {
+	return runStreamed(ctx, "", out, "aws", "ecr", "describe-images", "--region", a.region,
+		"--repository-name", a.repoName(), "--image-ids", fmt.Sprintf("imageTag=%s", shortID(taskID)))
+}
+
+// Rollback deletes the pushed image from ECR so a sibling target's failure
+// under an atomic DeploymentPolicy doesn't leave this one half-replicated.
+func (a *ECRAdapter) Rollback(ctx context.Context, taskID string, result *Result, out io.Writer) error {
+	return runStreamed(ctx, "", out, "aws", "ecr", "batch-delete-image", "--region", a.region,
+		"--repository-name", a.repoName(), "--image-ids", fmt.Sprintf("imageTag=%s", shortID(taskID)))
+}
@@ -0,0 +1,71 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/catalyst/backend/internal/config"
+)
+
+// K8sAdapter applies the same Deployment+Service manifest as
+// KubernetesTarget, but against a namespace named by the target's own
+// config rather than cfg.KubeNamespace — e.g. "staging" and "prod" as two
+// targets in one DeploymentPolicy.
+type K8sAdapter struct {
+	namespace string
+}
+
+func NewK8sAdapter(targetConfig map[string]string, cfg *config.Config) *K8sAdapter {
+	namespace := targetConfig["namespace"]
+	if namespace == "" {
+		namespace = cfg.KubeNamespace
+	}
+	return &K8sAdapter{namespace: namespace}
+}
+
+func (a *K8sAdapter) Name() string { return "k8s" }
+
+// Prepare is a no-op: kubectl apply in Push is idempotent and needs no
+// separate staging step.
+func (a *K8sAdapter) Prepare(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) error {
+	return nil
+}
+
+func (a *K8sAdapter) Push(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) (*Result, error) {
+	name := resourceName(taskID)
+
+	var manifest bytes.Buffer
+	if err := k8sTmpl.Execute(&manifest, struct {
+		Name      string
+		Namespace string
+		Image     string
+	}{Name: name, Namespace: a.namespace, Image: imageRef}); err != nil {
+		return nil, fmt.Errorf("k8s: render manifest: %w", err)
+	}
+
+	if err := runPiped(ctx, workspace, manifest.String(), out, "kubectl", "apply", "-f", "-"); err != nil {
+		return nil, fmt.Errorf("k8s: apply: %w", err)
+	}
+
+	return &Result{
+		URL: fmt.Sprintf("http://%s.%s.svc.cluster.local", name, a.namespace),
+		Ref: imageRef,
+	}, nil
+}
+
+func (a *K8sAdapter) Verify(ctx context.Context, taskID string, result *Result, out io.Writer) error {
+	name := resourceName(taskID)
+	return runStreamed(ctx, "", out, "kubectl", "rollout", "status", fmt.Sprintf("deployment/%s", name),
+		"-n", a.namespace, "--timeout=120s")
+}
+
+// Rollback deletes the Deployment and Service this target's Push applied so
+// a sibling target's failure under an atomic DeploymentPolicy doesn't leave
+// this one live on its own.
+func (a *K8sAdapter) Rollback(ctx context.Context, taskID string, result *Result, out io.Writer) error {
+	name := resourceName(taskID)
+	return runStreamed(ctx, "", out, "kubectl", "delete", fmt.Sprintf("deployment/%s", name), fmt.Sprintf("service/%s", name),
+		"-n", a.namespace, "--ignore-not-found")
+}
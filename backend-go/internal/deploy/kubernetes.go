@@ -0,0 +1,112 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"text/template"
+
+	"github.com/catalyst/backend/internal/config"
+)
+
+var k8sTmpl = template.Must(template.New("k8s").Parse(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{.Name}}
+  namespace: {{.Namespace}}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: {{.Name}}
+  template:
+    metadata:
+      labels:
+        app: {{.Name}}
+    spec:
+      containers:
+        - name: app
+          image: {{.Image}}
+          ports:
+            - containerPort: 8080
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{.Name}}
+  namespace: {{.Namespace}}
+spec:
+  selector:
+    app: {{.Name}}
+  ports:
+    - port: 80
+      targetPort: 8080
+`))
+
+// KubernetesTarget deploys by applying a rendered Deployment+Service
+// manifest via kubectl and waiting for the rollout to finish before
+// reporting the Service's cluster-internal URL.
+type KubernetesTarget struct {
+	namespace string
+}
+
+func NewKubernetesTarget(cfg *config.Config) *KubernetesTarget {
+	return &KubernetesTarget{namespace: cfg.KubeNamespace}
+}
+
+func (t *KubernetesTarget) Name() string { return "kubernetes" }
+
+func (t *KubernetesTarget) Deploy(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) (*Result, error) {
+	name := resourceName(taskID)
+
+	var manifest bytes.Buffer
+	if err := k8sTmpl.Execute(&manifest, struct {
+		Name      string
+		Namespace string
+		Image     string
+	}{Name: name, Namespace: t.namespace, Image: imageRef}); err != nil {
+		return nil, fmt.Errorf("render manifest: %w", err)
+	}
+
+	if err := runPiped(ctx, workspace, manifest.String(), out, "kubectl", "apply", "-f", "-"); err != nil {
+		return nil, fmt.Errorf("kubectl apply: %w", err)
+	}
+
+	rollout := fmt.Sprintf("deployment/%s", name)
+	if err := runStreamed(ctx, workspace, out, "kubectl", "rollout", "status", rollout,
+		"-n", t.namespace, "--timeout=120s"); err != nil {
+		return nil, fmt.Errorf("kubectl rollout status: %w", err)
+	}
+
+	return &Result{
+		URL: fmt.Sprintf("http://%s.%s.svc.cluster.local", name, t.namespace),
+		Ref: imageRef,
+	}, nil
+}
+
+// resourceName turns a task ID into a valid, short Kubernetes resource
+// name (lowercase alphanumerics and '-' only).
+func resourceName(taskID string) string {
+	clean := strings.ToLower(strings.ReplaceAll(taskID, "_", "-"))
+	if len(clean) > 8 {
+		clean = clean[:8]
+	}
+	return "catalyst-" + clean
+}
+
+// runPiped is runStreamed plus stdin, for commands that read their input
+// from the pipe rather than a file or an argv flag — manifests (kubectl
+// apply -f -) as well as secrets (docker login --password-stdin), which
+// must never be passed as a command-line argument since that leaks them
+// to any local user via ps/proc.
+func runPiped(ctx context.Context, workspace, stdin string, out io.Writer, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = workspace
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
@@ -0,0 +1,89 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/catalyst/backend/internal/config"
+	"github.com/catalyst/backend/internal/sandbox"
+)
+
+// Pipeline runs a CoderAgent artifact through the same shape as a CI
+// runner: materialize, build, push (for image-based targets), apply.
+type Pipeline struct {
+	cfg *config.Config
+}
+
+func NewPipeline(cfg *config.Config) *Pipeline {
+	return &Pipeline{cfg: cfg}
+}
+
+// Run builds code and ships it via target, streaming every command's
+// output to out. It returns the Target's Result plus the wall-clock
+// duration the whole build+push+apply took, for Cost.
+func (p *Pipeline) Run(ctx context.Context, taskID, code string, target Target, out io.Writer) (*Result, time.Duration, error) {
+	started := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.DeployTimeout)
+	defer cancel()
+
+	workspace, imageRef, err := materializeAndBuild(ctx, p.cfg, taskID, code, out)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer os.RemoveAll(workspace)
+
+	result, err := target.Deploy(ctx, taskID, workspace, imageRef, out)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s deploy: %w", target.Name(), err)
+	}
+
+	return result, time.Since(started), nil
+}
+
+// materializeAndBuild is the materialize+build half of Run, shared with
+// Replicator (which fans the same built artifact out to more than one
+// Adapter instead of a single Target). The caller owns removing the
+// returned workspace.
+func materializeAndBuild(ctx context.Context, cfg *config.Config, taskID, code string, out io.Writer) (workspace, imageRef string, err error) {
+	workspace, err = sandbox.Materialize(sandbox.ParseArtifact(code))
+	if err != nil {
+		return "", "", fmt.Errorf("materialize artifact: %w", err)
+	}
+
+	rec, err := detectRecipe(workspace)
+	if err != nil {
+		os.RemoveAll(workspace)
+		return "", "", err
+	}
+
+	if rec.producesImg {
+		imageRef = fmt.Sprintf("%s/catalyst-%s:latest", registryHost(cfg.RegistryURL), shortID(taskID))
+		if err := buildAndPush(ctx, cfg, workspace, imageRef, out); err != nil {
+			os.RemoveAll(workspace)
+			return "", "", err
+		}
+	} else if err := runStreamed(ctx, workspace, out, rec.cmd[0], rec.cmd[1:]...); err != nil {
+		os.RemoveAll(workspace)
+		return "", "", fmt.Errorf("build: %w", err)
+	}
+
+	return workspace, imageRef, nil
+}
+
+func registryHost(registryURL string) string {
+	if registryURL == "" {
+		return "localhost:5000"
+	}
+	return registryURL
+}
+
+func shortID(taskID string) string {
+	if len(taskID) > 8 {
+		return taskID[:8]
+	}
+	return taskID
+}
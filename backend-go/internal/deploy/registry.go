@@ -0,0 +1,33 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/catalyst/backend/internal/config"
+)
+
+// buildAndPush builds the workspace's Dockerfile as imageRef and pushes it
+// to cfg's registry, streaming both commands' output to out. It's shared
+// by the docker-compose and kubernetes targets, which both ship a
+// container image; static-s3 has no use for it.
+func buildAndPush(ctx context.Context, cfg *config.Config, workspace, imageRef string, out io.Writer) error {
+	if err := runStreamed(ctx, workspace, out, "docker", "build", "-t", imageRef, "."); err != nil {
+		return fmt.Errorf("docker build: %w", err)
+	}
+
+	if cfg.RegistryURL != "" {
+		if cfg.RegistryUser != "" {
+			if err := runPiped(ctx, workspace, cfg.RegistryPass, out, "docker", "login", cfg.RegistryURL,
+				"-u", cfg.RegistryUser, "--password-stdin"); err != nil {
+				return fmt.Errorf("docker login: %w", err)
+			}
+		}
+		if err := runStreamed(ctx, workspace, out, "docker", "push", imageRef); err != nil {
+			return fmt.Errorf("docker push: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/catalyst/backend/internal/config"
+)
+
+// AdapterOutcome is one target's result from a Replicator run — enough for
+// a caller to persist its own per-target record (see
+// models.DeploymentExecution).
+type AdapterOutcome struct {
+	Target   string
+	Result   *Result
+	Err      error
+	Duration time.Duration
+}
+
+// succeededTarget is Replicator's own bookkeeping for rollback: the
+// Adapter instance that produced Result, so Rollback can be called on the
+// exact same target config that succeeded, plus the index of its entry in
+// Run's outcomes slice so rollback can flip it back to failed.
+type succeededTarget struct {
+	adapter      Adapter
+	result       *Result
+	outcomeIndex int
+}
+
+// Replicator is Pipeline's multi-target counterpart: it builds an artifact
+// once, then runs it through every target in a DeploymentPolicy via
+// Adapter, in place of Pipeline's single Target.
+type Replicator struct {
+	cfg *config.Config
+}
+
+func NewReplicator(cfg *config.Config) *Replicator {
+	return &Replicator{cfg: cfg}
+}
+
+// Run builds code once and ships it to every target in policy, in order,
+// streaming every command's combined output to out. It always returns one
+// AdapterOutcome per target it attempted, including a failed one, but
+// stops attempting further targets once an atomic policy's rollback has
+// fired. Run itself only returns an error if the build never produced an
+// artifact to ship; a target failing is reported through its Outcome, not
+// through the returned error.
+func (r *Replicator) Run(ctx context.Context, taskID, code string, policy DeploymentPolicy, out io.Writer) ([]AdapterOutcome, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.DeployTimeout)
+	defer cancel()
+
+	workspace, imageRef, err := materializeAndBuild(ctx, r.cfg, taskID, code, out)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workspace)
+
+	var outcomes []AdapterOutcome
+	var succeeded []succeededTarget
+
+	for _, tc := range policy.Targets {
+		started := time.Now()
+
+		adapter, err := NewAdapter(tc.Scheme, tc.Config, r.cfg)
+		if err != nil {
+			outcomes = append(outcomes, AdapterOutcome{Target: tc.Scheme, Err: err, Duration: time.Since(started)})
+			if policy.Atomic {
+				r.rollback(ctx, taskID, succeeded, outcomes, out)
+				break
+			}
+			continue
+		}
+
+		result, runErr := r.runOne(ctx, adapter, taskID, workspace, imageRef, out)
+		outcomes = append(outcomes, AdapterOutcome{
+			Target:   adapter.Name(),
+			Result:   result,
+			Err:      runErr,
+			Duration: time.Since(started),
+		})
+
+		if runErr != nil {
+			if policy.Atomic {
+				r.rollback(ctx, taskID, succeeded, outcomes, out)
+				break
+			}
+			continue
+		}
+		succeeded = append(succeeded, succeededTarget{adapter: adapter, result: result, outcomeIndex: len(outcomes) - 1})
+	}
+
+	return outcomes, nil
+}
+
+func (r *Replicator) runOne(ctx context.Context, adapter Adapter, taskID, workspace, imageRef string, out io.Writer) (*Result, error) {
+	if err := adapter.Prepare(ctx, taskID, workspace, imageRef, out); err != nil {
+		return nil, fmt.Errorf("%s prepare: %w", adapter.Name(), err)
+	}
+
+	result, err := adapter.Push(ctx, taskID, workspace, imageRef, out)
+	if err != nil {
+		return nil, fmt.Errorf("%s push: %w", adapter.Name(), err)
+	}
+
+	if err := adapter.Verify(ctx, taskID, result, out); err != nil {
+		return result, fmt.Errorf("%s verify: %w", adapter.Name(), err)
+	}
+
+	return result, nil
+}
+
+// rollback undoes every already-succeeded target, in reverse order, when a
+// later target fails under an atomic DeploymentPolicy. A rolled-back
+// target is no longer a live success, so its outcomes entry is flipped
+// back to failed — otherwise the caller would report it (and its URL) as
+// a successful deployment even though Rollback just tore it down.
+func (r *Replicator) rollback(ctx context.Context, taskID string, succeeded []succeededTarget, outcomes []AdapterOutcome, out io.Writer) {
+	for i := len(succeeded) - 1; i >= 0; i-- {
+		t := succeeded[i]
+		outcomeErr := fmt.Errorf("%s: rolled back after atomic policy failure", t.adapter.Name())
+		if rollbackErr := t.adapter.Rollback(ctx, taskID, t.result, out); rollbackErr != nil {
+			fmt.Fprintf(out, "rollback %s failed: %v\n", t.adapter.Name(), rollbackErr)
+			outcomeErr = fmt.Errorf("%s: rolled back after atomic policy failure (rollback itself failed: %w)", t.adapter.Name(), rollbackErr)
+		}
+		outcomes[t.outcomeIndex].Result = nil
+		outcomes[t.outcomeIndex].Err = outcomeErr
+	}
+}
@@ -0,0 +1,50 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/catalyst/backend/internal/config"
+)
+
+// S3Target deploys a static build's output directory to a bucket via the
+// aws CLI, for projects with no server component to run.
+type S3Target struct {
+	bucket string
+}
+
+func NewS3Target(cfg *config.Config) *S3Target {
+	return &S3Target{bucket: cfg.S3Bucket}
+}
+
+func (t *S3Target) Name() string { return "static-s3" }
+
+// Deploy syncs workspace's static output (checked in build-output order of
+// preference: dist/, build/, public/) to the task's prefix in the bucket.
+// imageRef is unused — static-s3 ships files, not an image.
+func (t *S3Target) Deploy(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) (*Result, error) {
+	outputDir, err := staticOutputDir(workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("s3://%s/%s", t.bucket, taskID)
+	if err := runStreamed(ctx, workspace, out, "aws", "s3", "sync", outputDir, prefix, "--delete"); err != nil {
+		return nil, fmt.Errorf("aws s3 sync: %w", err)
+	}
+
+	return &Result{
+		URL: fmt.Sprintf("https://%s.s3.amazonaws.com/%s/index.html", t.bucket, taskID),
+		Ref: taskID,
+	}, nil
+}
+
+func staticOutputDir(workspace string) (string, error) {
+	for _, candidate := range []string{"dist", "build", "public"} {
+		if exists(workspace, candidate) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("deploy: no dist/build/public output directory found in %s", workspace)
+}
@@ -0,0 +1,57 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/catalyst/backend/internal/config"
+)
+
+// VercelAdapter ships a build to Vercel via its CLI, for a DeploymentPolicy
+// target that wants an edge-hosted URL rather than a container running
+// somewhere catalyst operates.
+type VercelAdapter struct {
+	project string
+	token   string
+}
+
+func NewVercelAdapter(targetConfig map[string]string, cfg *config.Config) *VercelAdapter {
+	return &VercelAdapter{
+		project: targetConfig["project"],
+		token:   targetConfig["token"],
+	}
+}
+
+func (a *VercelAdapter) Name() string { return "vercel" }
+
+// env returns the VERCEL_TOKEN environment variable runStreamedEnv sets
+// for the vercel CLI, rather than passing the token as a --token argv
+// flag, which would leak it to any local user via ps/proc.
+func (a *VercelAdapter) env() []string {
+	return []string{"VERCEL_TOKEN=" + a.token}
+}
+
+// Prepare links workspace to the target's Vercel project before deploying.
+// imageRef is unused — Vercel builds the workspace itself rather than
+// shipping a pre-built container image.
+func (a *VercelAdapter) Prepare(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) error {
+	return runStreamedEnv(ctx, workspace, a.env(), out, "vercel", "pull", "--yes")
+}
+
+func (a *VercelAdapter) Push(ctx context.Context, taskID, workspace, imageRef string, out io.Writer) (*Result, error) {
+	if err := runStreamedEnv(ctx, workspace, a.env(), out, "vercel", "deploy", "--prod", "--yes"); err != nil {
+		return nil, fmt.Errorf("vercel: deploy: %w", err)
+	}
+	return &Result{URL: fmt.Sprintf("https://%s.vercel.app", a.project), Ref: taskID}, nil
+}
+
+func (a *VercelAdapter) Verify(ctx context.Context, taskID string, result *Result, out io.Writer) error {
+	return runStreamedEnv(ctx, "", a.env(), out, "vercel", "inspect", result.URL)
+}
+
+// Rollback points the Vercel project back at its previous production
+// deployment.
+func (a *VercelAdapter) Rollback(ctx context.Context, taskID string, result *Result, out io.Writer) error {
+	return runStreamedEnv(ctx, "", a.env(), out, "vercel", "rollback", a.project)
+}
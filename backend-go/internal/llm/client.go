@@ -1,11 +1,14 @@
 package llm
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -28,14 +31,147 @@ func NewClient(apiKey, provider, model string) *Client {
 	}
 }
 
-func (c *Client) SendMessage(systemPrompt, userPrompt string) (string, error) {
+// SendMessage blocks for the LLM's full response. ctx is threaded into the
+// underlying HTTP request so a cancelled task aborts the call instead of
+// running to completion in the background.
+func (c *Client) SendMessage(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
 	if c.Provider == "anthropic" {
-		return c.callClaude(systemPrompt, userPrompt)
+		return c.callClaude(ctx, systemPrompt, userPrompt)
 	}
 	return "", fmt.Errorf("unsupported provider: %s", c.Provider)
 }
 
-func (c *Client) callClaude(systemPrompt, userPrompt string) (string, error) {
+// Delta is one incremental update from a streaming completion: Text holds
+// the next chunk of generated text, TokensIn/TokensOut are the latest token
+// counts reported by the API (0 until the API has reported one), and Finish
+// is set on the final Delta once the stream ends.
+type Delta struct {
+	Text      string
+	TokensIn  int
+	TokensOut int
+	Finish    bool
+}
+
+// StreamMessage is SendMessage's token-level counterpart: it sets
+// "stream": true on the request and emits one Delta per SSE
+// content_block_delta event, plus a final Delta with Finish set, on the
+// returned channel. The channel is closed when the stream ends, ctx is
+// cancelled, or the connection drops.
+func (c *Client) StreamMessage(ctx context.Context, systemPrompt, userPrompt string) (<-chan Delta, error) {
+	if c.Provider == "anthropic" {
+		return c.streamClaude(ctx, systemPrompt, userPrompt)
+	}
+	return nil, fmt.Errorf("unsupported provider: %s", c.Provider)
+}
+
+func (c *Client) streamClaude(ctx context.Context, systemPrompt, userPrompt string) (<-chan Delta, error) {
+	url := "https://api.anthropic.com/v1/messages"
+
+	reqBody := map[string]interface{}{
+		"model":      c.Model,
+		"max_tokens": 4096,
+		"system":     systemPrompt,
+		"stream":     true,
+		"messages": []Message{
+			{Role: "user", Content: userPrompt},
+		},
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", c.APIKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+	req.Header.Set("Accept", "text/event-stream")
+
+	// No client-side timeout: a long generation can legitimately run for
+	// minutes; ctx cancellation is what bounds it.
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("API error: %s", string(body))
+	}
+
+	deltas := make(chan Delta)
+	go func() {
+		defer close(deltas)
+		defer resp.Body.Close()
+
+		var tokensIn, tokensOut int
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			data := strings.TrimPrefix(scanner.Text(), "data: ")
+			if data == "" || data == scanner.Text() {
+				continue // blank line or not an SSE data line
+			}
+
+			var event map[string]interface{}
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				continue
+			}
+
+			switch event["type"] {
+			case "message_start":
+				if msg, ok := event["message"].(map[string]interface{}); ok {
+					if usage, ok := msg["usage"].(map[string]interface{}); ok {
+						tokensIn = intField(usage["input_tokens"])
+					}
+				}
+			case "content_block_delta":
+				delta, ok := event["delta"].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				text, _ := delta["text"].(string)
+				if text == "" {
+					continue
+				}
+				select {
+				case deltas <- Delta{Text: text, TokensIn: tokensIn, TokensOut: tokensOut}:
+				case <-ctx.Done():
+					return
+				}
+			case "message_delta":
+				if usage, ok := event["usage"].(map[string]interface{}); ok {
+					tokensOut = intField(usage["output_tokens"])
+				}
+			case "message_stop":
+				select {
+				case deltas <- Delta{TokensIn: tokensIn, TokensOut: tokensOut, Finish: true}:
+				case <-ctx.Done():
+				}
+				return
+			}
+		}
+	}()
+
+	return deltas, nil
+}
+
+func intField(v interface{}) int {
+	if f, ok := v.(float64); ok {
+		return int(f)
+	}
+	return 0
+}
+
+func (c *Client) callClaude(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
 	url := "https://api.anthropic.com/v1/messages"
 
 	reqBody := map[string]interface{}{
@@ -52,7 +188,7 @@ func (c *Client) callClaude(systemPrompt, userPrompt string) (string, error) {
 		return "", err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,53 @@
+package logstream
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// MaxLogsUpload caps how many bytes of log content a single task may push
+// through logstream for its entire run, across every agent and every
+// LineWriter created for it. Once hit, further content is silently
+// dropped instead of letting a chatty LLM or a runaway build keep flooding
+// Mongo and WebSocket clients. Each LineWriter that runs into the cap
+// emits one truncation marker, so a task with several writers can log
+// more than one marker.
+const MaxLogsUpload = 8 << 20 // 8 MiB
+
+// budgets tracks bytes spent so far per task ID, as *int64 counters
+// manipulated atomically.
+var budgets sync.Map
+
+// charge reserves up to n bytes of taskID's remaining budget and returns
+// how many of them were actually granted — less than n once the task is
+// near its cap, zero once it's exhausted.
+func charge(taskID string, n int) int {
+	if n <= 0 {
+		return n
+	}
+
+	v, _ := budgets.LoadOrStore(taskID, new(int64))
+	used := v.(*int64)
+
+	for {
+		cur := atomic.LoadInt64(used)
+		if cur >= MaxLogsUpload {
+			return 0
+		}
+		remaining := int64(MaxLogsUpload) - cur
+		allowed := int64(n)
+		if allowed > remaining {
+			allowed = remaining
+		}
+		if atomic.CompareAndSwapInt64(used, cur, cur+allowed) {
+			return int(allowed)
+		}
+	}
+}
+
+// ResetBudget clears taskID's upload budget, freeing it once the task's
+// pipeline run has finished so a later retry of the same task ID starts
+// with a fresh cap.
+func ResetBudget(taskID string) {
+	budgets.Delete(taskID)
+}
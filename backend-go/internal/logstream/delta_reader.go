@@ -0,0 +1,48 @@
+package logstream
+
+import (
+	"io"
+
+	"github.com/catalyst/backend/internal/llm"
+)
+
+// DeltaReader adapts a channel of llm.Delta values — as produced by
+// llm.Client.StreamMessage — into an io.Reader, so the streamed text can be
+// piped through io.Copy (typically via io.LimitReader into a LineWriter). It
+// also remembers the latest token counts reported by the stream so callers
+// can log them once the copy finishes.
+type DeltaReader struct {
+	deltas  <-chan llm.Delta
+	pending []byte
+
+	tokensIn  int
+	tokensOut int
+}
+
+func NewDeltaReader(deltas <-chan llm.Delta) *DeltaReader {
+	return &DeltaReader{deltas: deltas}
+}
+
+func (r *DeltaReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		d, ok := <-r.deltas
+		if !ok {
+			return 0, io.EOF
+		}
+		r.tokensIn, r.tokensOut = d.TokensIn, d.TokensOut
+		if d.Finish {
+			return 0, io.EOF
+		}
+		r.pending = []byte(d.Text)
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// Tokens returns the latest input/output token counts reported by the
+// stream, valid once Read has returned io.EOF.
+func (r *DeltaReader) Tokens() (in, out int) {
+	return r.tokensIn, r.tokensOut
+}
@@ -0,0 +1,228 @@
+// Package logstream batches agent output — both token-by-token LLM deltas
+// and one-off status lines — into line-sized chunks and periodically
+// flushes them to Mongo as one bulk insert plus one WebSocket push per
+// line, instead of a round-trip per token or per status message. A
+// per-task byte budget (MaxLogsUpload) and a bounded queue keep a chatty
+// LLM or a runaway build from flooding Mongo or stalling the agent
+// goroutine behind a slow WebSocket client.
+package logstream
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/catalyst/backend/internal/database"
+	"github.com/catalyst/backend/internal/models"
+	"github.com/catalyst/backend/internal/websocket"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxStreamBytes caps how much text a single streamed response can push
+	// through a LineWriter — wrap io.LimitReader(src, MaxStreamBytes) around
+	// whatever is copied into one, so a runaway generation can't flood
+	// Mongo or a WS subscriber on its own. MaxLogsUpload is the coarser cap
+	// across a task's entire run.
+	MaxStreamBytes = 2 << 20 // 2 MiB
+
+	defaultFlushInterval = 500 * time.Millisecond
+	defaultMaxBatch      = 50
+	queueCapacity        = 256
+
+	truncationMarker = "⚠️ log output truncated: task exceeded its upload limit"
+)
+
+// LineWriter is an io.Writer that buffers streamed text, splits it into
+// whole lines, and queues them onto a bounded channel that a background
+// loop drains into Mongo/WebSocket on a timer or once maxBatch lines have
+// piled up, whichever comes first. Once the channel is full, the oldest
+// queued line is dropped to make room for the newest rather than blocking
+// the writer — for a log stream, keeping up matters more than catching up.
+type LineWriter struct {
+	db        *database.Database
+	wsManager *websocket.Manager
+	taskID    string
+	agentName string
+
+	flushInterval time.Duration
+	maxBatch      int
+
+	mu        sync.Mutex
+	partial   bytes.Buffer
+	truncated bool
+
+	lines    chan models.AgentLog
+	flushNow chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewLineWriter starts the background flush loop; callers must call Close
+// to flush any remaining buffered output and stop that loop. agentName is
+// used to attribute lines written via the io.Writer interface (Write); use
+// WriteLine to attribute a one-off line to a different agent on a writer
+// shared across several (see Log).
+func NewLineWriter(db *database.Database, wsManager *websocket.Manager, taskID, agentName string) *LineWriter {
+	w := &LineWriter{
+		db:            db,
+		wsManager:     wsManager,
+		taskID:        taskID,
+		agentName:     agentName,
+		flushInterval: defaultFlushInterval,
+		maxBatch:      defaultMaxBatch,
+		lines:         make(chan models.AgentLog, queueCapacity),
+		flushNow:      make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go w.flushLoop()
+	return w
+}
+
+// Write buffers p — after charging it against taskID's MaxLogsUpload
+// budget, truncating it if that budget is exhausted mid-write — and queues
+// any complete lines it contains for the next flush; a trailing partial
+// line is held until a later Write completes it or Close flushes it as-is.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.bufferAndQueue(w.agentName, p)
+	return len(p), nil
+}
+
+// WriteLine attributes a single complete line to agentName and queues it,
+// subject to the same per-task budget as Write. It's how one-off agent
+// status messages (see Log) share a task's writer without being tied to
+// the agentName a streaming Write was constructed with.
+func (w *LineWriter) WriteLine(agentName, line string) {
+	w.bufferAndQueue(agentName, []byte(line+"\n"))
+}
+
+func (w *LineWriter) bufferAndQueue(agentName string, p []byte) {
+	allowed := charge(w.taskID, len(p))
+	data := p
+	if allowed < len(p) {
+		data = p[:allowed]
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial.Write(data)
+	for {
+		buf := w.partial.Bytes()
+		idx := bytes.IndexByte(buf, '\n')
+		if idx < 0 {
+			break
+		}
+		w.enqueue(w.logFor(agentName, string(buf[:idx])))
+		w.partial.Next(idx + 1)
+	}
+
+	if allowed < len(p) && !w.truncated {
+		w.truncated = true
+		w.enqueue(w.logFor(agentName, truncationMarker))
+	}
+}
+
+// enqueue must be called with w.mu held (it's only ever reached from
+// bufferAndQueue, to keep partial-buffer mutation and queueing ordered).
+func (w *LineWriter) enqueue(entry models.AgentLog) {
+	select {
+	case w.lines <- entry:
+	default:
+		select {
+		case <-w.lines:
+		default:
+		}
+		select {
+		case w.lines <- entry:
+		default:
+		}
+	}
+
+	if len(w.lines) >= w.maxBatch {
+		select {
+		case w.flushNow <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (w *LineWriter) logFor(agentName, line string) models.AgentLog {
+	return models.AgentLog{
+		ID:        uuid.New().String(),
+		TaskID:    w.taskID,
+		AgentName: agentName,
+		Message:   line,
+		Timestamp: time.Now(),
+	}
+}
+
+func (w *LineWriter) flushLoop() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.flush()
+		case <-w.flushNow:
+			w.flush()
+		case <-w.stop:
+			w.flush()
+			return
+		}
+	}
+}
+
+func (w *LineWriter) flush() {
+	var batch []models.AgentLog
+	for len(batch) < w.maxBatch {
+		select {
+		case line := <-w.lines:
+			batch = append(batch, line)
+		default:
+			goto drained
+		}
+	}
+drained:
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	docs := make([]interface{}, len(batch))
+	for i, line := range batch {
+		docs[i] = line
+	}
+	w.db.Logs.InsertMany(ctx, docs)
+
+	for _, line := range batch {
+		w.wsManager.SendLog(w.taskID, map[string]interface{}{
+			"task_id":    line.TaskID,
+			"agent_name": line.AgentName,
+			"message":    line.Message,
+			"timestamp":  line.Timestamp.Format(time.RFC3339),
+		})
+	}
+}
+
+// Close flushes any trailing partial line, stops the background flush
+// loop, and blocks until that final flush completes.
+func (w *LineWriter) Close() error {
+	w.mu.Lock()
+	if w.partial.Len() > 0 {
+		w.enqueue(w.logFor(w.agentName, w.partial.String()))
+		w.partial.Reset()
+	}
+	w.mu.Unlock()
+
+	close(w.stop)
+	<-w.done
+	return nil
+}
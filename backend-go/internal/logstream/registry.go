@@ -0,0 +1,52 @@
+package logstream
+
+import (
+	"sync"
+
+	"github.com/catalyst/backend/internal/database"
+	"github.com/catalyst/backend/internal/websocket"
+)
+
+// writers caches one shared LineWriter per task so the many one-off status
+// lines agents log over a task's lifetime (Planner, Architect, Reviewer,
+// Explorer, and Deployer/Coder/Tester's non-streaming messages) batch
+// through a single flush loop rather than each opening and closing its own.
+var (
+	writersMu sync.Mutex
+	writers   = make(map[string]*LineWriter)
+)
+
+// Log writes a single status line for taskID, reusing that task's shared
+// LineWriter if one is already open or creating it otherwise. This is what
+// agent log() helpers funnel through so long deploy/build output can't
+// stall the orchestrator behind a blocking Mongo insert or WebSocket send.
+func Log(db *database.Database, wsManager *websocket.Manager, taskID, agentName, message string) {
+	writerFor(db, wsManager, taskID).WriteLine(agentName, message)
+}
+
+func writerFor(db *database.Database, wsManager *websocket.Manager, taskID string) *LineWriter {
+	writersMu.Lock()
+	defer writersMu.Unlock()
+
+	if w, ok := writers[taskID]; ok {
+		return w
+	}
+	w := NewLineWriter(db, wsManager, taskID, "")
+	writers[taskID] = w
+	return w
+}
+
+// CloseTask flushes and closes taskID's shared writer (if any) and frees
+// its upload budget. Call once a task's pipeline run has finished so a
+// later retry of the same task ID starts clean.
+func CloseTask(taskID string) {
+	writersMu.Lock()
+	w, ok := writers[taskID]
+	delete(writers, taskID)
+	writersMu.Unlock()
+
+	if ok {
+		w.Close()
+	}
+	ResetBudget(taskID)
+}
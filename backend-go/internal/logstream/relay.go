@@ -0,0 +1,72 @@
+package logstream
+
+import (
+	"context"
+	"time"
+
+	"github.com/catalyst/backend/internal/database"
+	"github.com/catalyst/backend/internal/models"
+	"github.com/catalyst/backend/internal/websocket"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// relayPollInterval is how often RunRelay checks agent_logs for anything
+// new — on the same order as LineWriter's own flush interval, since that's
+// how fresh these rows can be in the first place.
+const relayPollInterval = 300 * time.Millisecond
+
+// RunRelay polls agent_logs for lines inserted since the last poll and
+// forwards each to wsManager.SendLog. Before the cmd/server / cmd/worker
+// split, LineWriter.flush pushed straight into the same process's
+// websocket.Manager; now that lines are usually persisted by a worker
+// process with no WebSocket clients of its own, the API process runs this
+// instead so browser subscribers keep seeing live log lines regardless of
+// which worker owns the task. Blocks until ctx is cancelled.
+func RunRelay(ctx context.Context, db *database.Database, wsManager *websocket.Manager) {
+	since := time.Now()
+
+	ticker := time.NewTicker(relayPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			since = relayOnce(ctx, db, wsManager, since)
+		}
+	}
+}
+
+func relayOnce(ctx context.Context, db *database.Database, wsManager *websocket.Manager, since time.Time) time.Time {
+	cursor, err := db.Logs.Find(ctx,
+		bson.M{"timestamp": bson.M{"$gt": since}},
+		options.Find().SetSort(bson.D{{"timestamp", 1}}),
+	)
+	if err != nil {
+		logger.Errorf("logstream: failed to poll agent logs: %v", err)
+		return since
+	}
+	defer cursor.Close(ctx)
+
+	latest := since
+	var line models.AgentLog
+	for cursor.Next(ctx) {
+		if err := cursor.Decode(&line); err != nil {
+			logger.Errorf("logstream: failed to decode agent log: %v", err)
+			continue
+		}
+		wsManager.SendLog(line.TaskID, map[string]interface{}{
+			"task_id":    line.TaskID,
+			"agent_name": line.AgentName,
+			"message":    line.Message,
+			"timestamp":  line.Timestamp.Format(time.RFC3339),
+		})
+		if line.Timestamp.After(latest) {
+			latest = line.Timestamp
+		}
+	}
+	return latest
+}
@@ -5,21 +5,60 @@ import (
 )
 
 type Project struct {
-	ID          string    `json:"id" bson:"id"`
-	Name        string    `json:"name" bson:"name" binding:"required"`
-	Description string    `json:"description" bson:"description"`
-	Status      string    `json:"status" bson:"status"`
-	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	ID          string `json:"id" bson:"id"`
+	Name        string `json:"name" bson:"name" binding:"required"`
+	Description string `json:"description" bson:"description"`
+	Status      string `json:"status" bson:"status"`
+	// DeployTarget picks the deploy.Target DeployerAgent uses for this
+	// project's tasks: "docker-compose", "kubernetes", or "static-s3".
+	// Empty falls back to config.Config.DefaultDeployTarget.
+	DeployTarget string `json:"deploy_target" bson:"deploy_target"`
+	// DeploymentPolicy, if set, replaces DeployTarget's single deploy.Target
+	// with a fan-out across every target it lists (see deploy.Replicator).
+	DeploymentPolicy *DeploymentPolicy `json:"deployment_policy,omitempty" bson:"deployment_policy,omitempty"`
+	CreatedAt        time.Time         `json:"created_at" bson:"created_at"`
+}
+
+// TargetConfig names one Adapter a DeploymentPolicy fans a build out to
+// (by deploy.registry scheme, e.g. "ecr", "k8s"), plus its per-target
+// settings such as "repository" or "namespace", layered over
+// config.Config's global defaults for that adapter.
+type TargetConfig struct {
+	Scheme string            `json:"scheme" bson:"scheme"`
+	Config map[string]string `json:"config" bson:"config"`
+}
+
+// DeploymentPolicy lets a project replicate a single build across more
+// than one environment in one task — e.g. staging + prod, or AWS + GCP —
+// instead of the single deploy.Target Project.DeployTarget names.
+type DeploymentPolicy struct {
+	Targets []TargetConfig `json:"targets" bson:"targets"`
+	// Atomic rolls every already-succeeded target back if a later one in
+	// Targets fails, so the policy either lands everywhere or nowhere.
+	Atomic bool `json:"atomic" bson:"atomic"`
 }
 
 type Task struct {
-	ID         string                 `json:"id" bson:"id"`
-	ProjectID  string                 `json:"project_id" bson:"project_id" binding:"required"`
-	Prompt     string                 `json:"prompt" bson:"prompt" binding:"required"`
-	GraphState map[string]string      `json:"graph_state" bson:"graph_state"`
-	Status     string                 `json:"status" bson:"status"`
-	Cost       float64                `json:"cost" bson:"cost"`
-	CreatedAt  time.Time              `json:"created_at" bson:"created_at"`
+	ID         string            `json:"id" bson:"id"`
+	ProjectID  string            `json:"project_id" bson:"project_id" binding:"required"`
+	Prompt     string            `json:"prompt" bson:"prompt" binding:"required"`
+	GraphState map[string]string `json:"graph_state" bson:"graph_state"`
+	// NodeOutputs holds every completed node's result payload, keyed by
+	// node name, alongside GraphState's per-node status. The reaper
+	// (queue.ReapExpiredTasks) replays these into the interpreter on the
+	// next attempt instead of re-running the whole DAG, so a crash after
+	// e.g. the deployer finishes doesn't redo a real deploy.
+	NodeOutputs map[string]map[string]interface{} `json:"node_outputs,omitempty" bson:"node_outputs,omitempty"`
+	Status      string                             `json:"status" bson:"status"`
+	Cost        float64                            `json:"cost" bson:"cost"`
+	// LeaseOwner, LeaseExpiresAt and LastHeartbeat mirror the owning
+	// Delivery's lease (see queue.Queue) onto the Task itself so operators
+	// and the reaper (queue.ReapExpiredTasks) can tell a task is alive, or
+	// notice a crashed worker, without joining through Deliveries.
+	LeaseOwner     string    `json:"lease_owner" bson:"lease_owner"`
+	LeaseExpiresAt time.Time `json:"lease_expires_at" bson:"lease_expires_at"`
+	LastHeartbeat  time.Time `json:"last_heartbeat" bson:"last_heartbeat"`
+	CreatedAt      time.Time `json:"created_at" bson:"created_at"`
 }
 
 type AgentLog struct {
@@ -46,12 +85,18 @@ type ExplorerScan struct {
 	Brief      string    `json:"brief" bson:"brief"`
 	Risks      []string  `json:"risks" bson:"risks"`
 	Proposals  []string  `json:"proposals" bson:"proposals"`
-	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
+	// ArtifactRefs cites the sources.SystemArtifacts the brief/risks/
+	// proposals were synthesized from ("git:README.md", "jira:PROJ-12",
+	// "confluence:98307"), so downstream agents can point back at evidence.
+	ArtifactRefs []string  `json:"artifact_refs" bson:"artifact_refs"`
+	CreatedAt    time.Time `json:"created_at" bson:"created_at"`
 }
 
 type ProjectCreate struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description"`
+	Name             string            `json:"name" binding:"required"`
+	Description      string            `json:"description"`
+	DeployTarget     string            `json:"deploy_target"`
+	DeploymentPolicy *DeploymentPolicy `json:"deployment_policy"`
 }
 
 type TaskCreate struct {
@@ -60,7 +105,63 @@ type TaskCreate struct {
 }
 
 type ExplorerScanCreate struct {
-	SystemName  string `json:"system_name" binding:"required"`
-	RepoURL     string `json:"repo_url"`
-	JiraProject string `json:"jira_project"`
+	SystemName      string `json:"system_name" binding:"required"`
+	RepoURL         string `json:"repo_url"`
+	JiraProject     string `json:"jira_project"`
+	ConfluenceSpace string `json:"confluence_space"`
+}
+
+// Delivery is a unit of pending work handed out to exactly one worker at a
+// time via a leased claim. It lives in the "deliveries" collection so any
+// backend replica can pick up a task after a restart.
+type Delivery struct {
+	ID             string    `json:"id" bson:"id"`
+	TaskID         string    `json:"task_id" bson:"task_id"`
+	Phase          string    `json:"phase" bson:"phase"`
+	Status         string    `json:"status" bson:"status"`
+	ClaimedBy      string    `json:"claimed_by" bson:"claimed_by"`
+	LeaseExpiresAt time.Time `json:"lease_expires_at" bson:"lease_expires_at"`
+	Attempts       int       `json:"attempts" bson:"attempts"`
+	CreatedAt      time.Time `json:"created_at" bson:"created_at"`
+}
+
+// ControlEvent is a durable record of a cancel/pause/resume frame the API
+// accepted over a task's WebSocket, written to the "control_events"
+// collection so a cmd/worker process — which doesn't hold that WebSocket
+// connection itself — can pick it up via bus.Relay.
+type ControlEvent struct {
+	ID        string    `json:"id" bson:"id"`
+	TaskID    string    `json:"task_id" bson:"task_id"`
+	Action    string    `json:"action" bson:"action"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// DeploymentExecution is one target's outcome from a DeploymentPolicy
+// fan-out (see deploy.Replicator), recorded in the
+// "deployment_executions" collection separately from Deployment so the UI
+// can show per-environment status rather than only the primary target's.
+type DeploymentExecution struct {
+	ID         string    `json:"id" bson:"id"`
+	TaskID     string    `json:"task_id" bson:"task_id"`
+	Target     string    `json:"target" bson:"target"`
+	Status     string    `json:"status" bson:"status"` // "success" or "failed"
+	StartedAt  time.Time `json:"started_at" bson:"started_at"`
+	FinishedAt time.Time `json:"finished_at" bson:"finished_at"`
+	Error      string    `json:"error" bson:"error"`
+	Cost       float64   `json:"cost" bson:"cost"`
+}
+
+// PipelineDefinition is a project's custom pipeline DAG, uploaded as raw
+// YAML via POST /api/pipelines. A project without one runs pipeline.Default().
+type PipelineDefinition struct {
+	ID        string    `json:"id" bson:"id"`
+	ProjectID string    `json:"project_id" bson:"project_id" binding:"required"`
+	Name      string    `json:"name" bson:"name"`
+	YAML      string    `json:"yaml" bson:"yaml" binding:"required"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+type PipelineCreate struct {
+	ProjectID string `json:"project_id" binding:"required"`
+	YAML      string `json:"yaml" binding:"required"`
 }
\ No newline at end of file
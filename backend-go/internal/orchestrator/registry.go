@@ -0,0 +1,106 @@
+package orchestrator
+
+import (
+	"context"
+	"sync"
+)
+
+// TaskRegistry tracks the live cancellation context and pause state for
+// tasks currently executing in this process, so an inbound bus.Event
+// (cancel/pause/resume) can reach the in-flight pipeline run for that task.
+type TaskRegistry struct {
+	mu      sync.Mutex
+	entries map[string]*taskControl
+}
+
+type taskControl struct {
+	cancel context.CancelFunc
+	paused bool
+	resume chan struct{}
+}
+
+func NewTaskRegistry() *TaskRegistry {
+	return &TaskRegistry{entries: make(map[string]*taskControl)}
+}
+
+// Register derives a cancellable context from parent for taskID and stores
+// it so Cancel/Pause/Resume can act on it for the lifetime of the run. The
+// caller must call Unregister once the run finishes.
+func (r *TaskRegistry) Register(parent context.Context, taskID string) context.Context {
+	ctx, cancel := context.WithCancel(parent)
+
+	r.mu.Lock()
+	r.entries[taskID] = &taskControl{cancel: cancel, resume: make(chan struct{})}
+	r.mu.Unlock()
+
+	return ctx
+}
+
+// Unregister drops taskID's entry once its run has finished. It cancels
+// the entry's context first — Register's context.WithCancel derives from
+// the long-lived worker ctx, and an uncancelled child stays registered in
+// that parent's internal child list for the rest of the process's life.
+func (r *TaskRegistry) Unregister(taskID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tc, ok := r.entries[taskID]; ok {
+		tc.cancel()
+	}
+	delete(r.entries, taskID)
+}
+
+// Cancel aborts taskID's in-flight run, if any.
+func (r *TaskRegistry) Cancel(taskID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tc, ok := r.entries[taskID]; ok {
+		tc.cancel()
+	}
+}
+
+// Pause marks taskID as paused; WaitIfPaused blocks until Resume is called.
+func (r *TaskRegistry) Pause(taskID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tc, ok := r.entries[taskID]; ok {
+		tc.paused = true
+	}
+}
+
+// Resume un-pauses taskID, releasing any call blocked in WaitIfPaused.
+func (r *TaskRegistry) Resume(taskID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tc, ok := r.entries[taskID]
+	if !ok || !tc.paused {
+		return
+	}
+	tc.paused = false
+	close(tc.resume)
+	tc.resume = make(chan struct{})
+}
+
+// WaitIfPaused blocks the caller while taskID is paused, returning early if
+// ctx is cancelled.
+func (r *TaskRegistry) WaitIfPaused(ctx context.Context, taskID string) {
+	for {
+		r.mu.Lock()
+		tc, ok := r.entries[taskID]
+		if !ok || !tc.paused {
+			r.mu.Unlock()
+			return
+		}
+		resume := tc.resume
+		r.mu.Unlock()
+
+		select {
+		case <-resume:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,373 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/catalyst/backend/internal/agents"
+	"github.com/catalyst/backend/internal/config"
+	"github.com/catalyst/backend/internal/database"
+	"github.com/catalyst/backend/internal/websocket"
+)
+
+// NodeFunc runs one pipeline node. outputs holds every node's result so far,
+// keyed by node name, so a node can read whatever fields its dependencies
+// produced (e.g. outputs["architect"]["architecture"]). ctx is cancelled if
+// the task is cancelled mid-run, which aborts any in-flight LLM call.
+type NodeFunc func(ctx context.Context, taskID string, outputs map[string]map[string]interface{}) (map[string]interface{}, error)
+
+// reservedInputNode is the node name Run seeds with the task's prompt and
+// project ID before the DAG executes; it never runs through the registry,
+// so pipeline.Load's agent-name validation exempts it.
+const reservedInputNode = "input"
+
+// KnownAgents is every agent name NewInterpreter's registry wires up.
+// pipeline.Load validates a custom definition's node.Agent fields against
+// this set at upload time so a misspelled or unsupported agent is
+// rejected with a 400 instead of uploading successfully and then failing
+// every task for that project at runtime with "no agent registered for
+// %q". Keep this in sync with the registry built in NewInterpreter.
+var KnownAgents = map[string]bool{
+	"planner":   true,
+	"architect": true,
+	"coder":     true,
+	"tester":    true,
+	"reviewer":  true,
+	"deployer":  true,
+}
+
+// Interpreter resolves a Pipeline's DAG and executes it: nodes whose
+// dependencies are all satisfied run concurrently, a `when` guard can skip a
+// node, and a node can retry by re-running its own dependencies when its
+// output carries one of the signals listed in its retry policy.
+type Interpreter struct {
+	registry     map[string]NodeFunc
+	onTransition func(node, status string)
+	onOutput     func(node string, out map[string]interface{})
+	waitIfPaused func(ctx context.Context)
+}
+
+// NewInterpreter wires the built-in agents into the node registry used by
+// the default pipeline and any custom pipeline that references the same
+// agent names. onTransition is called at every node's start/skip/completion
+// so callers can mirror it into graph state, logs, etc. onOutput is called
+// once a node's result is final (so callers can persist it for a crash to
+// resume from later, see queue.ReapExpiredTasks); pass nil to not persist
+// outputs. waitIfPaused is called between phases so a paused task blocks
+// there until resumed; pass nil to never pause.
+func NewInterpreter(db *database.Database, wsManager *websocket.Manager, cfg *config.Config, onTransition func(node, status string), onOutput func(node string, out map[string]interface{}), waitIfPaused func(ctx context.Context)) *Interpreter {
+	planner := agents.NewPlannerAgent(db, wsManager, cfg)
+	architect := agents.NewArchitectAgent(db, wsManager, cfg)
+	coder := agents.NewCoderAgent(db, wsManager, cfg)
+	tester := agents.NewTesterAgent(db, wsManager, cfg)
+	reviewer := agents.NewReviewerAgent(db, wsManager, cfg)
+	deployer := agents.NewDeployerAgent(db, wsManager, cfg)
+
+	registry := map[string]NodeFunc{
+		"planner": func(ctx context.Context, taskID string, outputs map[string]map[string]interface{}) (map[string]interface{}, error) {
+			prompt, _ := outputs["input"]["prompt"].(string)
+			plan, err := planner.Plan(ctx, taskID, prompt)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"plan": plan}, nil
+		},
+		"architect": func(ctx context.Context, taskID string, outputs map[string]map[string]interface{}) (map[string]interface{}, error) {
+			plan, _ := outputs["planner"]["plan"].(string)
+			architecture, err := architect.Design(ctx, taskID, plan)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"architecture": architecture}, nil
+		},
+		"coder": func(ctx context.Context, taskID string, outputs map[string]map[string]interface{}) (map[string]interface{}, error) {
+			architecture, _ := outputs["architect"]["architecture"].(string)
+			feedback := ""
+			if prev, ok := outputs["tester"]; ok {
+				if failures, _ := prev["failures"].(string); failures != "" {
+					feedback = failures
+				} else {
+					feedback, _ = prev["result"].(string)
+				}
+			}
+			code, err := coder.Code(ctx, taskID, architecture, feedback)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"code": code}, nil
+		},
+		"tester": func(ctx context.Context, taskID string, outputs map[string]map[string]interface{}) (map[string]interface{}, error) {
+			code, _ := outputs["coder"]["code"].(string)
+			result, report, err := tester.Test(ctx, taskID, code)
+			if err != nil {
+				return nil, err
+			}
+			out := map[string]interface{}{"result": result, "passed": report.Ok(), "report": report}
+			if !report.Ok() {
+				out["retry_signal"] = "tests_failed"
+				out["failures"] = report.Feedback()
+			}
+			return out, nil
+		},
+		"reviewer": func(ctx context.Context, taskID string, outputs map[string]map[string]interface{}) (map[string]interface{}, error) {
+			code, _ := outputs["coder"]["code"].(string)
+			testResult, _ := outputs["tester"]["result"].(string)
+			review, err := reviewer.Review(ctx, taskID, code, testResult)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"review": review}, nil
+		},
+		"deployer": func(ctx context.Context, taskID string, outputs map[string]map[string]interface{}) (map[string]interface{}, error) {
+			code, _ := outputs["coder"]["code"].(string)
+			projectID, _ := outputs["input"]["project_id"].(string)
+			url, commitSHA, err := deployer.Deploy(ctx, taskID, code, projectID)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"url": url, "commit_sha": commitSHA}, nil
+		},
+	}
+
+	return &Interpreter{registry: registry, onTransition: onTransition, onOutput: onOutput, waitIfPaused: waitIfPaused}
+}
+
+// Run executes p for taskID, seeding the graph with the task's prompt and
+// project ID under the reserved "input" node, and returns every node's
+// output keyed by node name. resume carries any node outputs already
+// persisted from an earlier, interrupted attempt at this same task (keyed
+// by node name, as returned by a previous Run and mirrored out via
+// onOutput); those nodes are treated as already completed and Run picks
+// up from whatever's left instead of re-running the whole DAG. Pass nil
+// for a fresh run.
+func (i *Interpreter) Run(ctx context.Context, taskID, prompt, projectID string, p *Pipeline, resume map[string]map[string]interface{}) (map[string]map[string]interface{}, error) {
+	byName := make(map[string]Node, len(p.Nodes))
+	for _, n := range p.Nodes {
+		byName[n.Name] = n
+	}
+
+	outputs := map[string]map[string]interface{}{
+		reservedInputNode: {"prompt": prompt, "project_id": projectID},
+	}
+	var mu sync.RWMutex
+	completed := map[string]bool{reservedInputNode: true}
+	for name, out := range resume {
+		if _, ok := byName[name]; !ok {
+			continue
+		}
+		outputs[name] = out
+		completed[name] = true
+	}
+
+	for len(completed) < len(byName) {
+		select {
+		case <-ctx.Done():
+			return outputs, ctx.Err()
+		default:
+		}
+
+		if i.waitIfPaused != nil {
+			i.waitIfPaused(ctx)
+		}
+		if ctx.Err() != nil {
+			return outputs, ctx.Err()
+		}
+
+		runnable := i.runnableNodes(byName, completed)
+		if len(runnable) == 0 {
+			return outputs, fmt.Errorf("pipeline %s: no runnable nodes left (missing dependency or cycle)", p.Name)
+		}
+
+		type result struct {
+			name    string
+			out     map[string]interface{}
+			err     error
+			skipped bool
+		}
+		results := make([]result, len(runnable))
+
+		var wg sync.WaitGroup
+		for idx, n := range runnable {
+			wg.Add(1)
+			go func(idx int, n Node) {
+				defer wg.Done()
+
+				run, err := evalWhen(n.When, snapshot(outputs, &mu))
+				if err != nil {
+					results[idx] = result{name: n.Name, err: err}
+					return
+				}
+				if !run {
+					i.notify(n.Name, "skipped")
+					results[idx] = result{name: n.Name, out: map[string]interface{}{}, skipped: true}
+					return
+				}
+
+				i.notify(n.Name, "running")
+				out, err := i.runNodeWithRetry(ctx, taskID, n, byName, &mu, outputs)
+				results[idx] = result{name: n.Name, out: out, err: err}
+			}(idx, n)
+		}
+		wg.Wait()
+
+		for _, r := range results {
+			if r.out != nil {
+				mu.Lock()
+				outputs[r.name] = r.out
+				mu.Unlock()
+			}
+			completed[r.name] = true
+
+			if r.err != nil {
+				i.notify(r.name, "failed")
+				return outputs, fmt.Errorf("node %s: %w", r.name, r.err)
+			}
+			if !r.skipped {
+				i.notify(r.name, "completed")
+				i.notifyOutput(r.name, r.out)
+			}
+		}
+	}
+
+	return outputs, nil
+}
+
+func (i *Interpreter) runnableNodes(byName map[string]Node, completed map[string]bool) []Node {
+	var runnable []Node
+	for name, n := range byName {
+		if completed[name] {
+			continue
+		}
+		ready := true
+		for _, dep := range n.DependsOn {
+			if !completed[dep] {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			runnable = append(runnable, n)
+		}
+	}
+	return runnable
+}
+
+// runNodeWithRetry runs n's agent function; if the result carries a signal
+// listed in n.Retry.On, it re-runs n's dependencies (so they can pick up
+// the failing node's feedback, e.g. the coder reading the tester's output)
+// and retries n itself, up to n.Retry.Max times.
+func (i *Interpreter) runNodeWithRetry(ctx context.Context, taskID string, n Node, byName map[string]Node, mu *sync.RWMutex, outputs map[string]map[string]interface{}) (map[string]interface{}, error) {
+	fn, ok := i.registry[n.Agent]
+	if !ok {
+		return nil, fmt.Errorf("no agent registered for %q", n.Agent)
+	}
+
+	attempt := 0
+	for {
+		attempt++
+		out, err := fn(ctx, taskID, snapshot(outputs, mu))
+		if err != nil {
+			return out, err
+		}
+		if !shouldRetry(n, out) {
+			return out, nil
+		}
+		if attempt > n.Retry.Max {
+			return out, fmt.Errorf("retries exhausted after %d attempt(s)", attempt)
+		}
+
+		mu.Lock()
+		outputs[n.Name] = out
+		mu.Unlock()
+
+		for _, dep := range n.DependsOn {
+			depNode, ok := byName[dep]
+			if !ok {
+				continue
+			}
+			i.notify(depNode.Name, "reworking")
+			i.notify(depNode.Name, "running")
+			depOut, depErr := i.runNodeWithRetry(ctx, taskID, depNode, byName, mu, outputs)
+			if depErr != nil {
+				i.notify(depNode.Name, "failed")
+				return out, fmt.Errorf("node %s: %w", depNode.Name, depErr)
+			}
+			i.notify(depNode.Name, "completed")
+			i.notifyOutput(depNode.Name, depOut)
+			mu.Lock()
+			outputs[dep] = depOut
+			mu.Unlock()
+		}
+	}
+}
+
+func shouldRetry(n Node, out map[string]interface{}) bool {
+	if len(n.Retry.On) == 0 {
+		return false
+	}
+	signal, _ := out["retry_signal"].(string)
+	if signal == "" {
+		return false
+	}
+	for _, s := range n.Retry.On {
+		if s == signal {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *Interpreter) notify(node, status string) {
+	if i.onTransition != nil {
+		i.onTransition(node, status)
+	}
+}
+
+func (i *Interpreter) notifyOutput(node string, out map[string]interface{}) {
+	if i.onOutput != nil {
+		i.onOutput(node, out)
+	}
+}
+
+func snapshot(outputs map[string]map[string]interface{}, mu *sync.RWMutex) map[string]map[string]interface{} {
+	mu.RLock()
+	defer mu.RUnlock()
+	cp := make(map[string]map[string]interface{}, len(outputs))
+	for k, v := range outputs {
+		cp[k] = v
+	}
+	return cp
+}
+
+// evalWhen supports the minimal "<node>.<field> == <literal>" form needed
+// to route around optional nodes (e.g. "tester.passed == true"). An empty
+// expression always runs.
+func evalWhen(expr string, outputs map[string]map[string]interface{}) (bool, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return true, nil
+	}
+
+	parts := strings.SplitN(expr, "==", 2)
+	if len(parts) != 2 {
+		return false, fmt.Errorf("unsupported when expression: %q", expr)
+	}
+
+	lhs := strings.TrimSpace(parts[0])
+	rhs := strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+
+	dot := strings.Index(lhs, ".")
+	if dot < 0 {
+		return false, fmt.Errorf("unsupported when expression: %q", expr)
+	}
+	node, field := lhs[:dot], lhs[dot+1:]
+
+	nodeOut, ok := outputs[node]
+	if !ok {
+		return false, nil
+	}
+
+	return fmt.Sprint(nodeOut[field]) == rhs, nil
+}
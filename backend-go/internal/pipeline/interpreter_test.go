@@ -0,0 +1,76 @@
+package pipeline
+
+import "testing"
+
+func TestEvalWhen(t *testing.T) {
+	outputs := map[string]map[string]interface{}{
+		"tester": {"passed": true, "attempt": 2},
+	}
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    bool
+		wantErr bool
+	}{
+		{"empty expression always runs", "", true, false},
+		{"matching bool literal", `tester.passed == true`, true, false},
+		{"non-matching bool literal", `tester.passed == false`, false, false},
+		{"matching quoted string", `tester.passed == "true"`, true, false},
+		{"unknown node never runs", `deployer.passed == true`, false, false},
+		{"malformed expression", "tester.passed", false, true},
+		{"missing dot", "nodotatall == true", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evalWhen(tt.expr, outputs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("evalWhen() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("evalWhen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		n    Node
+		out  map[string]interface{}
+		want bool
+	}{
+		{
+			name: "no retry policy",
+			n:    Node{Name: "coder"},
+			out:  map[string]interface{}{"retry_signal": "tests_failed"},
+			want: false,
+		},
+		{
+			name: "signal matches policy",
+			n:    Node{Name: "tester", Retry: RetryConfig{Max: 2, On: []string{"tests_failed"}}},
+			out:  map[string]interface{}{"retry_signal": "tests_failed"},
+			want: true,
+		},
+		{
+			name: "signal doesn't match policy",
+			n:    Node{Name: "tester", Retry: RetryConfig{Max: 2, On: []string{"review_rejected"}}},
+			out:  map[string]interface{}{"retry_signal": "tests_failed"},
+			want: false,
+		},
+		{
+			name: "no signal in output",
+			n:    Node{Name: "tester", Retry: RetryConfig{Max: 2, On: []string{"tests_failed"}}},
+			out:  map[string]interface{}{},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldRetry(tt.n, tt.out); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
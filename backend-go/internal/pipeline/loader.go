@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed default.yaml
+var defaultYAML []byte
+
+// Default returns the built-in pipeline that reproduces the original
+// hardcoded phase sequence, used whenever a project hasn't uploaded its own
+// definition.
+func Default() (*Pipeline, error) {
+	return Load(defaultYAML)
+}
+
+// Load parses and validates a pipeline definition from YAML bytes.
+func Load(data []byte) (*Pipeline, error) {
+	var p Pipeline
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("pipeline: invalid YAML: %w", err)
+	}
+
+	if err := validate(&p); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// LoadFile reads a pipeline definition from disk.
+func LoadFile(path string) (*Pipeline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Load(data)
+}
+
+func validate(p *Pipeline) error {
+	if len(p.Nodes) == 0 {
+		return fmt.Errorf("pipeline %q: must declare at least one node", p.Name)
+	}
+
+	seen := make(map[string]bool, len(p.Nodes))
+	for _, n := range p.Nodes {
+		if n.Name == "" {
+			return fmt.Errorf("pipeline %q: node missing a name", p.Name)
+		}
+		if seen[n.Name] {
+			return fmt.Errorf("pipeline %q: duplicate node name %q", p.Name, n.Name)
+		}
+		seen[n.Name] = true
+		if n.Name != reservedInputNode && !KnownAgents[n.Agent] {
+			return fmt.Errorf("pipeline %q: node %q references unknown agent %q", p.Name, n.Name, n.Agent)
+		}
+	}
+
+	for _, n := range p.Nodes {
+		for _, dep := range n.DependsOn {
+			if !seen[dep] {
+				return fmt.Errorf("pipeline %q: node %q depends on unknown node %q", p.Name, n.Name, dep)
+			}
+		}
+	}
+
+	return nil
+}
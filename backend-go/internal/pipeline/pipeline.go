@@ -0,0 +1,56 @@
+// Package pipeline interprets a declarative DAG of orchestration phases
+// loaded from YAML, replacing the hardcoded
+// planner->architect->coder<->tester->reviewer->deployer sequence that used
+// to be baked into the orchestrator. Nodes declare their dependencies, an
+// optional `when` guard, and a retry budget; independent nodes run
+// concurrently and a node can route back to its own dependencies for a
+// bounded number of retries (the coder<->tester rework loop being the
+// motivating case).
+package pipeline
+
+// RetryConfig bounds how many times a node is retried and which of its own
+// output signals trigger a retry (re-running its dependencies first).
+type RetryConfig struct {
+	Max int      `yaml:"max"`
+	On  []string `yaml:"on"`
+}
+
+// Node is a single step in the pipeline: it names the agent that runs it,
+// the nodes it depends on, an optional boolean guard, and a retry policy.
+type Node struct {
+	Name      string      `yaml:"name"`
+	Agent     string      `yaml:"agent"`
+	DependsOn []string    `yaml:"depends_on"`
+	When      string      `yaml:"when"`
+	Retry     RetryConfig `yaml:"retry"`
+	Labels    []string    `yaml:"labels"`
+}
+
+// Pipeline is the parsed form of a project's (or the global) pipeline YAML.
+type Pipeline struct {
+	Name  string `yaml:"name"`
+	Nodes []Node `yaml:"nodes"`
+}
+
+// TerminalNodes returns the name of every node in p that no other node
+// depends on — the DAG's leaf/terminal node(s). A task is only genuinely
+// finished once every terminal node has completed, so callers checking
+// whether a run's work is actually done (e.g. the queue reaper) must key
+// off this instead of a hardcoded node name like the default pipeline's
+// "deployer", which doesn't exist in a custom DAG.
+func TerminalNodes(p *Pipeline) []string {
+	hasDependent := make(map[string]bool, len(p.Nodes))
+	for _, n := range p.Nodes {
+		for _, dep := range n.DependsOn {
+			hasDependent[dep] = true
+		}
+	}
+
+	var terminal []string
+	for _, n := range p.Nodes {
+		if n.Name != reservedInputNode && !hasDependent[n.Name] {
+			terminal = append(terminal, n.Name)
+		}
+	}
+	return terminal
+}
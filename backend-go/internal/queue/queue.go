@@ -0,0 +1,142 @@
+// Package queue persists pending orchestration work in MongoDB so it
+// survives process restarts and can be shared across backend replicas.
+// A Delivery is claimed via an atomic FindOneAndUpdate that assigns a lease;
+// the claiming worker must keep renewing that lease (see Queue.Extend) for
+// as long as it holds the work, otherwise another worker will reclaim it
+// once the lease expires.
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/catalyst/backend/internal/database"
+	"github.com/catalyst/backend/internal/models"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// DefaultLeaseTTL is how long a worker holds exclusive claim on a
+	// delivery before another worker is allowed to steal it.
+	DefaultLeaseTTL = 60 * time.Second
+
+	// MaxAttempts bounds how many attempts (claims) a delivery gets: Fail
+	// marks it permanently failed once an attempt numbered MaxAttempts or
+	// higher fails.
+	MaxAttempts = 5
+
+	baseBackoff = 2 * time.Second
+	maxBackoff  = 5 * time.Minute
+)
+
+type Queue struct {
+	db *database.Database
+}
+
+func NewQueue(db *database.Database) *Queue {
+	return &Queue{db: db}
+}
+
+// Enqueue inserts a new pending delivery for taskID and returns its ID.
+func (q *Queue) Enqueue(ctx context.Context, taskID, phase string) (string, error) {
+	delivery := models.Delivery{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		Phase:     phase,
+		Status:    "pending",
+		Attempts:  0,
+		CreatedAt: time.Now(),
+	}
+
+	if _, err := q.db.Deliveries.InsertOne(ctx, delivery); err != nil {
+		return "", err
+	}
+
+	return delivery.ID, nil
+}
+
+// Claim atomically picks up the oldest delivery that is either unclaimed or
+// whose lease has expired, and assigns it to workerID for leaseTTL. It
+// returns (nil, nil) when there is no claimable work.
+func (q *Queue) Claim(ctx context.Context, workerID string, leaseTTL time.Duration) (*models.Delivery, error) {
+	filter := bson.M{
+		"status":           bson.M{"$in": []string{"pending", "claimed"}},
+		"lease_expires_at": bson.M{"$lte": time.Now()},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":           "claimed",
+			"claimed_by":       workerID,
+			"lease_expires_at": time.Now().Add(leaseTTL),
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{"created_at", 1}}).
+		SetReturnDocument(options.After)
+
+	var delivery models.Delivery
+	err := q.db.Deliveries.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// Extend renews the lease on a claimed delivery; call this on a ticker
+// while a worker is actively processing it so unrelated workers don't
+// steal it out from under a long-running phase.
+func (q *Queue) Extend(ctx context.Context, deliveryID string, ttl time.Duration) error {
+	_, err := q.db.Deliveries.UpdateOne(ctx,
+		bson.M{"id": deliveryID},
+		bson.M{"$set": bson.M{"lease_expires_at": time.Now().Add(ttl)}},
+	)
+	return err
+}
+
+// Complete marks a delivery as done.
+func (q *Queue) Complete(ctx context.Context, deliveryID string) error {
+	_, err := q.db.Deliveries.UpdateOne(ctx,
+		bson.M{"id": deliveryID},
+		bson.M{"$set": bson.M{"status": "completed"}},
+	)
+	return err
+}
+
+// Fail records a failed attempt. If the delivery still has attempts left it
+// is returned to the pending pool after an exponential backoff delay,
+// otherwise it is marked failed for good.
+func (q *Queue) Fail(ctx context.Context, delivery *models.Delivery) error {
+	if delivery.Attempts >= MaxAttempts {
+		_, err := q.db.Deliveries.UpdateOne(ctx,
+			bson.M{"id": delivery.ID},
+			bson.M{"$set": bson.M{"status": "failed"}},
+		)
+		return err
+	}
+
+	_, err := q.db.Deliveries.UpdateOne(ctx,
+		bson.M{"id": delivery.ID},
+		bson.M{"$set": bson.M{
+			"status":           "pending",
+			"lease_expires_at": time.Now().Add(backoffFor(delivery.Attempts)),
+		}},
+	)
+	return err
+}
+
+func backoffFor(attempts int) time.Duration {
+	d := baseBackoff << attempts
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
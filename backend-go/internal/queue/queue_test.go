@@ -0,0 +1,26 @@
+package queue
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{"first attempt", 0, baseBackoff},
+		{"second attempt doubles", 1, baseBackoff * 2},
+		{"third attempt doubles again", 2, baseBackoff * 4},
+		{"caps at maxBackoff", 20, maxBackoff},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backoffFor(tt.attempts); got != tt.want {
+				t.Errorf("backoffFor(%d) = %v, want %v", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
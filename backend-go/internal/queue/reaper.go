@@ -0,0 +1,122 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/catalyst/backend/internal/database"
+	"github.com/catalyst/backend/internal/models"
+	"github.com/catalyst/backend/internal/orchestrator"
+	"github.com/catalyst/backend/internal/pipeline"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// reapInterval is how often ReapExpiredTasks sweeps for abandoned tasks.
+const reapInterval = 30 * time.Second
+
+// RunReaper blocks, sweeping for abandoned tasks every reapInterval until
+// ctx is cancelled. Ordinarily a task's Delivery is reclaimed by the very
+// next worker that polls (see Queue.Claim's lease filter), which re-runs
+// processPhase and brings the task's status current on its own. This
+// reaper exists for the case that self-healing can't reach: a task left
+// "running" past its lease with no pending/claimed Delivery to reclaim it
+// at all, e.g. the Delivery was already marked completed or failed by a
+// worker that then crashed before it could update the Task.
+func RunReaper(ctx context.Context, db *database.Database) {
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ReapExpiredTasks(ctx, db)
+		}
+	}
+}
+
+// ReapExpiredTasks finds tasks stuck "running" past their lease with no
+// claimable Delivery and resolves each one: if its GraphState shows every
+// terminal node of its pipeline (see pipeline.TerminalNodes) already
+// completed, the task is marked completed (the crash happened after the
+// real work finished); otherwise a fresh Delivery is enqueued so another
+// worker picks it back up. That worker resumes rather than restarts: the
+// task's NodeOutputs already holds every node that finished before the
+// crash, and Interpreter.Run treats those as done and only re-runs what's
+// left (see pipeline.Interpreter.Run's resume parameter).
+func ReapExpiredTasks(ctx context.Context, db *database.Database) {
+	cursor, err := db.Tasks.Find(ctx, bson.M{
+		"status":           "running",
+		"lease_expires_at": bson.M{"$lte": time.Now()},
+	})
+	if err != nil {
+		logger.Errorf("Reaper: failed to scan for expired tasks: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []models.Task
+	if err := cursor.All(ctx, &tasks); err != nil {
+		logger.Errorf("Reaper: failed to decode expired tasks: %v", err)
+		return
+	}
+
+	for _, task := range tasks {
+		reapTask(ctx, db, task)
+	}
+}
+
+func reapTask(ctx context.Context, db *database.Database, task models.Task) {
+	claimable, err := db.Deliveries.CountDocuments(ctx, bson.M{
+		"task_id": task.ID,
+		"status":  bson.M{"$in": []string{"pending", "claimed"}},
+	})
+	if err != nil {
+		logger.Errorf("Reaper: failed to check deliveries for task %s: %v", task.ID, err)
+		return
+	}
+	if claimable > 0 {
+		// Still reachable through the ordinary Claim() path.
+		return
+	}
+
+	if taskReachedTerminalNodes(ctx, db, task) {
+		logger.Warnf("Reaper: task %s had no claimable delivery but finished before its owner died; marking completed", task.ID)
+		orchestrator.UpdateTaskStatus(db, task.ID, "completed", nil)
+		return
+	}
+
+	logger.Warnf("Reaper: task %s abandoned by %s with no claimable delivery; re-enqueuing to resume from its last completed node", task.ID, task.LeaseOwner)
+	q := NewQueue(db)
+	if _, err := q.Enqueue(ctx, task.ID, "pipeline"); err != nil {
+		logger.Errorf("Reaper: failed to re-enqueue task %s: %v", task.ID, err)
+		return
+	}
+	orchestrator.UpdateTaskStatus(db, task.ID, "pending", nil)
+}
+
+// taskReachedTerminalNodes reports whether every terminal node of task's
+// own pipeline (its project's custom DAG, or the default) has completed.
+// A pipeline that fails to load is treated as not finished, so the task
+// falls through to the normal re-enqueue path rather than being marked
+// completed on a guess.
+func taskReachedTerminalNodes(ctx context.Context, db *database.Database, task models.Task) bool {
+	def, err := loadPipeline(ctx, db, task.ProjectID)
+	if err != nil {
+		logger.Errorf("Reaper: failed to load pipeline for task %s: %v", task.ID, err)
+		return false
+	}
+
+	terminal := pipeline.TerminalNodes(def)
+	if len(terminal) == 0 {
+		return false
+	}
+	for _, name := range terminal {
+		if task.GraphState[name] != "completed" {
+			return false
+		}
+	}
+	return true
+}
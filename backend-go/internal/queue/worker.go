@@ -0,0 +1,239 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/catalyst/backend/internal/bus"
+	"github.com/catalyst/backend/internal/config"
+	"github.com/catalyst/backend/internal/database"
+	"github.com/catalyst/backend/internal/logstream"
+	"github.com/catalyst/backend/internal/models"
+	"github.com/catalyst/backend/internal/orchestrator"
+	"github.com/catalyst/backend/internal/pipeline"
+	"github.com/catalyst/backend/internal/websocket"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	logger "github.com/sirupsen/logrus"
+)
+
+const extendInterval = 20 * time.Second
+
+// Worker long-polls the queue for unclaimed deliveries and runs the
+// orchestrator phases against them. Multiple workers, in the same process
+// or across replicas, can run concurrently since claims are leased.
+type Worker struct {
+	ID           string
+	db           *database.Database
+	wsManager    *websocket.Manager
+	cfg          *config.Config
+	queue        *Queue
+	registry     *orchestrator.TaskRegistry
+	controlBus   *bus.Bus
+	leaseTTL     time.Duration
+	pollInterval time.Duration
+}
+
+// NewWorker builds a Worker. controlBus is where it listens for a running
+// task's cancel/pause/resume control frames; since cmd/worker runs as a
+// separate process from cmd/server's WebSocket handler, the caller is
+// expected to feed controlBus from bus.Relay rather than publish to it
+// directly in-process.
+func NewWorker(db *database.Database, wsManager *websocket.Manager, cfg *config.Config, controlBus *bus.Bus) *Worker {
+	return &Worker{
+		ID:           uuid.New().String(),
+		db:           db,
+		wsManager:    wsManager,
+		cfg:          cfg,
+		queue:        NewQueue(db),
+		registry:     orchestrator.NewTaskRegistry(),
+		controlBus:   controlBus,
+		leaseTTL:     DefaultLeaseTTL,
+		pollInterval: 2 * time.Second,
+	}
+}
+
+// Run blocks, claiming and processing deliveries until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	logger.Infof("Worker %s started", w.ID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Infof("Worker %s stopping", w.ID)
+			return
+		default:
+		}
+
+		delivery, err := w.queue.Claim(ctx, w.ID, w.leaseTTL)
+		if err != nil {
+			logger.Errorf("Worker %s failed to claim delivery: %v", w.ID, err)
+			time.Sleep(w.pollInterval)
+			continue
+		}
+		if delivery == nil {
+			time.Sleep(w.pollInterval)
+			continue
+		}
+
+		w.runDelivery(ctx, delivery)
+	}
+}
+
+func (w *Worker) runDelivery(ctx context.Context, delivery *models.Delivery) {
+	extendCtx, stopExtending := context.WithCancel(ctx)
+	defer stopExtending()
+
+	if err := orchestrator.UpdateTaskLease(w.db, delivery.TaskID, w.ID, time.Now().Add(w.leaseTTL)); err != nil {
+		logger.Errorf("Worker %s failed to stamp initial lease for task %s: %v", w.ID, delivery.TaskID, err)
+	}
+	go w.extendLease(extendCtx, delivery.ID, delivery.TaskID)
+
+	if err := w.processPhase(ctx, delivery); err != nil {
+		logger.Errorf("Worker %s failed delivery %s (attempt %d): %v", w.ID, delivery.ID, delivery.Attempts, err)
+		if failErr := w.queue.Fail(ctx, delivery); failErr != nil {
+			logger.Errorf("Worker %s failed to record failure for delivery %s: %v", w.ID, delivery.ID, failErr)
+		}
+		// Only reflect "failed" once Fail has also decided the delivery has
+		// no attempts left; otherwise it's about to be retried, so the task
+		// goes back to "pending" rather than reporting a failure that may
+		// still resolve into a success.
+		status := "pending"
+		if delivery.Attempts >= MaxAttempts {
+			status = "failed"
+		}
+		orchestrator.UpdateTaskStatus(w.db, delivery.TaskID, status, nil)
+		return
+	}
+
+	if err := w.queue.Complete(ctx, delivery.ID); err != nil {
+		logger.Errorf("Worker %s failed to complete delivery %s: %v", w.ID, delivery.ID, err)
+	}
+}
+
+// extendLease renews both the delivery's lease and the task's mirrored
+// lease/heartbeat fields on every tick. A failed renewal is logged and
+// otherwise ignored — ReapExpiredTasks only acts once the lease itself
+// expires, so a single missed heartbeat doesn't cost the task its claim.
+func (w *Worker) extendLease(ctx context.Context, deliveryID, taskID string) {
+	ticker := time.NewTicker(extendInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.queue.Extend(ctx, deliveryID, w.leaseTTL); err != nil {
+				logger.Errorf("Worker %s failed to extend lease for delivery %s: %v", w.ID, deliveryID, err)
+			}
+			if err := orchestrator.UpdateTaskLease(w.db, taskID, w.ID, time.Now().Add(w.leaseTTL)); err != nil {
+				logger.Errorf("Worker %s failed to extend lease for task %s: %v", w.ID, taskID, err)
+			}
+		}
+	}
+}
+
+// processPhase runs the delivery's task through its pipeline DAG: the
+// project's custom definition if it uploaded one via POST /api/pipelines,
+// otherwise pipeline.Default(). This used to be orchestrator.ExecuteTask's
+// hardcoded phase sequence, invoked directly as a goroutine from the API
+// handler; it now runs inside a leased worker so a crash mid-run lets
+// another worker pick it back up.
+//
+// It deliberately does not set the task's status to "failed" on error —
+// runDelivery decides that once queue.Fail has also decided the delivery
+// has no attempts left, since an error here may still just be a retry.
+func (w *Worker) processPhase(ctx context.Context, delivery *models.Delivery) (err error) {
+	taskID := delivery.TaskID
+
+	var task models.Task
+	if err := w.db.Tasks.FindOne(ctx, bson.M{"id": taskID}).Decode(&task); err != nil {
+		return err
+	}
+
+	// Derive a per-task cancel context and listen for control events
+	// (cancel/pause/resume) published over the WebSocket channel for the
+	// lifetime of this run.
+	taskCtx := w.registry.Register(ctx, taskID)
+	defer w.registry.Unregister(taskID)
+	defer logstream.CloseTask(taskID)
+
+	events, unsubscribe := w.controlBus.Subscribe(taskID)
+	defer unsubscribe()
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	go w.watchControl(watchCtx, taskID, events)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("Task execution panic: %v", r)
+			err = fmt.Errorf("task execution panic: %v", r)
+		}
+	}()
+
+	orchestrator.UpdateTaskStatus(w.db, taskID, "running", nil)
+
+	def, err := loadPipeline(ctx, w.db, task.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	interp := pipeline.NewInterpreter(w.db, w.wsManager, w.cfg, func(node, status string) {
+		orchestrator.UpdateGraphState(w.db, taskID, node, status)
+	}, func(node string, out map[string]interface{}) {
+		orchestrator.UpdateNodeOutput(w.db, taskID, node, out)
+	}, func(waitCtx context.Context) {
+		w.registry.WaitIfPaused(waitCtx, taskID)
+	})
+
+	if _, err := interp.Run(taskCtx, taskID, task.Prompt, task.ProjectID, def, task.NodeOutputs); err != nil {
+		if taskCtx.Err() != nil {
+			// Cancelled rather than failed: don't let queue.Fail retry it.
+			orchestrator.UpdateTaskStatus(w.db, taskID, "cancelled", nil)
+			return nil
+		}
+		return err
+	}
+
+	orchestrator.UpdateTaskStatusWithCost(w.db, taskID, "completed", 0.85)
+	return nil
+}
+
+// watchControl applies inbound control events to taskID's registry entry
+// until ctx is cancelled (the run finished) or the bus subscription closes.
+func (w *Worker) watchControl(ctx context.Context, taskID string, events <-chan bus.Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			switch ev.Action {
+			case "cancel":
+				w.registry.Cancel(taskID)
+			case "pause":
+				w.registry.Pause(taskID)
+			case "resume":
+				w.registry.Resume(taskID)
+			}
+		}
+	}
+}
+
+// loadPipeline returns the project's custom pipeline if it uploaded one,
+// falling back to the built-in default that reproduces the original fixed
+// phase sequence. It's a package-level function (rather than a *Worker
+// method) so the reaper can resolve a task's pipeline the same way without
+// needing a Worker of its own.
+func loadPipeline(ctx context.Context, db *database.Database, projectID string) (*pipeline.Pipeline, error) {
+	var def models.PipelineDefinition
+	err := db.Pipelines.FindOne(ctx, bson.M{"project_id": projectID}).Decode(&def)
+	if err != nil {
+		return pipeline.Default()
+	}
+	return pipeline.Load([]byte(def.YAML))
+}
@@ -0,0 +1,60 @@
+package sandbox
+
+import (
+	"regexp"
+	"strings"
+)
+
+// filePathLine matches a line that announces the file a following code
+// fence belongs to, in any of the forms the coder LLM tends to use:
+//
+//	File: main.go
+//	**main.go**
+//	### src/app.py
+var filePathLine = regexp.MustCompile(`(?i)^\s*(?:#{1,4}\s*)?(?:\*\*)?(?:file)?:?\s*` +
+	"`?" + `([\w./-]+\.\w+)` + "`?" + `(?:\*\*)?\s*$`)
+
+// fenceOpen matches a code fence's opening line, optionally carrying the
+// file path directly in the info string (e.g. "```go:main.go" or
+// "```main.go").
+var fenceOpen = regexp.MustCompile("^```(?:[\\w-]*:)?([\\w./-]+\\.\\w+)?[\\w-]*\\s*$")
+
+// ParseArtifact extracts file paths and contents from a CoderAgent response.
+// The LLM has no fixed output schema, so this looks for a path mentioned on
+// its own line (or in a fence's info string) immediately followed by a
+// fenced code block, and takes the fence body as that file's contents.
+// Fences it can't attribute to a path are skipped.
+func ParseArtifact(response string) map[string][]byte {
+	files := make(map[string][]byte)
+	lines := strings.Split(response, "\n")
+
+	var pendingPath string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := filePathLine.FindStringSubmatch(line); m != nil {
+			pendingPath = m[1]
+			continue
+		}
+
+		if m := fenceOpen.FindStringSubmatch(line); m != nil {
+			path := pendingPath
+			if m[1] != "" {
+				path = m[1]
+			}
+			pendingPath = ""
+
+			end := i + 1
+			for end < len(lines) && strings.TrimSpace(lines[end]) != "```" {
+				end++
+			}
+			if path != "" && end < len(lines) {
+				files[path] = []byte(strings.Join(lines[i+1:end], "\n"))
+			}
+			i = end
+			continue
+		}
+	}
+
+	return files
+}
@@ -0,0 +1,68 @@
+package sandbox
+
+import "testing"
+
+func TestParseArtifact(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     map[string]string
+	}{
+		{
+			name: "file header on its own line",
+			response: "File: main.go\n" +
+				"```go\n" +
+				"package main\n" +
+				"```\n",
+			want: map[string]string{"main.go": "package main"},
+		},
+		{
+			name: "path in fence info string",
+			response: "Here's the code:\n" +
+				"```go:main.go\n" +
+				"package main\n" +
+				"```\n",
+			want: map[string]string{"main.go": "package main"},
+		},
+		{
+			name: "bold markdown path",
+			response: "**src/app.py**\n" +
+				"```\n" +
+				"print(1)\n" +
+				"```\n",
+			want: map[string]string{"src/app.py": "print(1)"},
+		},
+		{
+			name: "fence with no attributable path is skipped",
+			response: "```\n" +
+				"some stray snippet\n" +
+				"```\n",
+			want: map[string]string{},
+		},
+		{
+			name: "multiple files",
+			response: "File: a.go\n" +
+				"```go\n" +
+				"package a\n" +
+				"```\n" +
+				"File: b.go\n" +
+				"```go\n" +
+				"package b\n" +
+				"```\n",
+			want: map[string]string{"a.go": "package a", "b.go": "package b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseArtifact(tt.response)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseArtifact() returned %d files, want %d (%v)", len(got), len(tt.want), got)
+			}
+			for path, want := range tt.want {
+				if string(got[path]) != want {
+					t.Errorf("file %q = %q, want %q", path, got[path], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package sandbox
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Failure describes one failing test case, as recovered from a test
+// runner's console output.
+type Failure struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	Stack   string `json:"stack"`
+}
+
+// TestReport is the structured result of running a code artifact's test
+// suite inside the sandbox, in place of the coin-flip Passed bool the
+// tester used to return. A retry is triggered whenever Ok is false:
+// Passed != Total, or nothing recognizable ran (Total 0).
+type TestReport struct {
+	Passed   int       `json:"passed"`
+	Failed   int       `json:"failed"`
+	Total    int       `json:"total"`
+	Failures []Failure `json:"failures"`
+}
+
+// Ok reports whether every test in the suite passed. A report with Total
+// 0 (nothing recognizable ran) is never Ok, so an unparsable run still
+// routes back to the coder instead of being treated as a free pass.
+func (r *TestReport) Ok() bool {
+	return r.Total > 0 && r.Passed == r.Total
+}
+
+// Feedback renders the report's failures as the plain-text feedback fed
+// back to CoderAgent.Code, so a retry sees the actual assertion messages
+// instead of a generic "tests failed".
+func (r *TestReport) Feedback() string {
+	if len(r.Failures) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(r.Failed))
+	b.WriteString(" of ")
+	b.WriteString(strconv.Itoa(r.Total))
+	b.WriteString(" tests failed:\n")
+	for _, f := range r.Failures {
+		b.WriteString("- ")
+		b.WriteString(f.Name)
+		if f.Message != "" {
+			b.WriteString(": ")
+			b.WriteString(f.Message)
+		}
+		b.WriteString("\n")
+		if f.Stack != "" {
+			b.WriteString(f.Stack)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+var (
+	goFailLine  = regexp.MustCompile(`^--- FAIL: (\S+) \(`)
+	goPassLine  = regexp.MustCompile(`^--- PASS: (\S+) \(`)
+	pyFailLine  = regexp.MustCompile(`^FAILED (\S+) - (.*)$`)
+	pySummary   = regexp.MustCompile(`(\d+) failed(?:, (\d+) passed)?|(\d+) passed`)
+	jestFailure = regexp.MustCompile(`^\s*✕ (.+)$`)
+	jestSummary = regexp.MustCompile(`Tests:\s+(?:(\d+) failed, )?(\d+) passed, (\d+) total`)
+)
+
+// parseGoTest turns `go test -v` output into a TestReport. A failing test's
+// message is the run of lines indented by four spaces that directly
+// follows its "--- FAIL" marker, up to the first line that isn't.
+func parseGoTest(output string) *TestReport {
+	lines := strings.Split(output, "\n")
+	report := &TestReport{}
+
+	for i := 0; i < len(lines); i++ {
+		if m := goPassLine.FindStringSubmatch(lines[i]); m != nil {
+			report.Passed++
+			report.Total++
+			continue
+		}
+		if m := goFailLine.FindStringSubmatch(lines[i]); m != nil {
+			report.Failed++
+			report.Total++
+
+			end := i + 1
+			for end < len(lines) && strings.HasPrefix(lines[end], "    ") {
+				end++
+			}
+			report.Failures = append(report.Failures, Failure{
+				Name:    m[1],
+				Message: strings.TrimSpace(strings.Join(lines[i+1:end], "\n")),
+			})
+			i = end - 1
+		}
+	}
+
+	return report
+}
+
+// parsePytest turns `pytest -v` output into a TestReport, reading the
+// "N failed, M passed" summary line for counts and the "FAILED ..." lines
+// for individual failures.
+func parsePytest(output string) *TestReport {
+	report := &TestReport{}
+
+	for _, line := range strings.Split(output, "\n") {
+		if m := pyFailLine.FindStringSubmatch(line); m != nil {
+			report.Failures = append(report.Failures, Failure{Name: m[1], Message: m[2]})
+			continue
+		}
+		if m := pySummary.FindStringSubmatch(line); m != nil {
+			if m[1] != "" {
+				failed, _ := strconv.Atoi(m[1])
+				report.Failed = failed
+			}
+			passed := m[2]
+			if passed == "" {
+				passed = m[3]
+			}
+			if passed != "" {
+				p, _ := strconv.Atoi(passed)
+				report.Passed = p
+			}
+			report.Total = report.Passed + report.Failed
+		}
+	}
+
+	return report
+}
+
+// parseJest turns jest's default console reporter output into a TestReport,
+// reading the "Tests: N failed, M passed, T total" summary line for counts
+// and "✕ name" lines for individual failures.
+func parseJest(output string) *TestReport {
+	report := &TestReport{}
+
+	for _, line := range strings.Split(output, "\n") {
+		if m := jestFailure.FindStringSubmatch(line); m != nil {
+			report.Failures = append(report.Failures, Failure{Name: strings.TrimSpace(m[1])})
+			continue
+		}
+		if m := jestSummary.FindStringSubmatch(line); m != nil {
+			if m[1] != "" {
+				failed, _ := strconv.Atoi(m[1])
+				report.Failed = failed
+			}
+			passed, _ := strconv.Atoi(m[2])
+			report.Passed = passed
+			total, _ := strconv.Atoi(m[3])
+			report.Total = total
+		}
+	}
+
+	return report
+}
+
+// parseOutput dispatches combined stdout+stderr to the parser for lang. An
+// unrecognized language yields an empty report (Total 0), which Ok treats
+// as a failure so an unparsable run still routes back to the coder.
+func parseOutput(lang string, output string) *TestReport {
+	switch lang {
+	case langGo:
+		return parseGoTest(output)
+	case langPython:
+		return parsePytest(output)
+	case langNode:
+		return parseJest(output)
+	default:
+		return &TestReport{}
+	}
+}
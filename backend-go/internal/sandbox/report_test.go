@@ -0,0 +1,157 @@
+package sandbox
+
+import "testing"
+
+func TestTestReportOk(t *testing.T) {
+	tests := []struct {
+		name string
+		r    TestReport
+		want bool
+	}{
+		{"all passed", TestReport{Passed: 3, Total: 3}, true},
+		{"some failed", TestReport{Passed: 2, Failed: 1, Total: 3}, false},
+		{"nothing ran", TestReport{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Ok(); got != tt.want {
+				t.Errorf("Ok() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGoTest(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		wantPassed int
+		wantFailed int
+		wantTotal  int
+		wantNames  []string
+	}{
+		{
+			name: "all pass",
+			output: "=== RUN   TestFoo\n" +
+				"--- PASS: TestFoo (0.00s)\n" +
+				"=== RUN   TestBar\n" +
+				"--- PASS: TestBar (0.00s)\n" +
+				"PASS\n",
+			wantPassed: 2,
+			wantTotal:  2,
+		},
+		{
+			name: "one failure with message",
+			output: "=== RUN   TestFoo\n" +
+				"--- FAIL: TestFoo (0.00s)\n" +
+				"    main_test.go:12: expected 1, got 2\n" +
+				"--- PASS: TestBar (0.00s)\n" +
+				"FAIL\n",
+			wantPassed: 1,
+			wantFailed: 1,
+			wantTotal:  2,
+			wantNames:  []string{"TestFoo"},
+		},
+		{
+			name:       "no recognizable output",
+			output:     "ok  \tsome/pkg\t0.003s\n",
+			wantPassed: 0,
+			wantFailed: 0,
+			wantTotal:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := parseGoTest(tt.output)
+			if report.Passed != tt.wantPassed || report.Failed != tt.wantFailed || report.Total != tt.wantTotal {
+				t.Errorf("parseGoTest() = %+v, want passed=%d failed=%d total=%d", report, tt.wantPassed, tt.wantFailed, tt.wantTotal)
+			}
+			if len(report.Failures) != len(tt.wantNames) {
+				t.Fatalf("got %d failures, want %d", len(report.Failures), len(tt.wantNames))
+			}
+			for i, name := range tt.wantNames {
+				if report.Failures[i].Name != name {
+					t.Errorf("failure[%d].Name = %q, want %q", i, report.Failures[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePytest(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		wantPassed int
+		wantFailed int
+		wantTotal  int
+	}{
+		{
+			name: "mixed results",
+			output: "FAILED test_foo.py::test_add - AssertionError: 1 != 2\n" +
+				"========== 1 failed, 2 passed in 0.12s ==========\n",
+			wantPassed: 2,
+			wantFailed: 1,
+			wantTotal:  3,
+		},
+		{
+			name:       "all passed, no failed count in summary",
+			output:     "========== 4 passed in 0.08s ==========\n",
+			wantPassed: 4,
+			wantFailed: 0,
+			wantTotal:  4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := parsePytest(tt.output)
+			if report.Passed != tt.wantPassed || report.Failed != tt.wantFailed || report.Total != tt.wantTotal {
+				t.Errorf("parsePytest() = %+v, want passed=%d failed=%d total=%d", report, tt.wantPassed, tt.wantFailed, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestParseJest(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		wantPassed int
+		wantFailed int
+		wantTotal  int
+		wantNames  []string
+	}{
+		{
+			name: "one failing test",
+			output: "  ✕ adds two numbers\n" +
+				"Tests:       1 failed, 2 passed, 3 total\n",
+			wantPassed: 2,
+			wantFailed: 1,
+			wantTotal:  3,
+			wantNames:  []string{"adds two numbers"},
+		},
+		{
+			name:       "all passed",
+			output:     "Tests:       3 passed, 3 total\n",
+			wantPassed: 3,
+			wantFailed: 0,
+			wantTotal:  3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := parseJest(tt.output)
+			if report.Passed != tt.wantPassed || report.Failed != tt.wantFailed || report.Total != tt.wantTotal {
+				t.Errorf("parseJest() = %+v, want passed=%d failed=%d total=%d", report, tt.wantPassed, tt.wantFailed, tt.wantTotal)
+			}
+			if len(report.Failures) != len(tt.wantNames) {
+				t.Fatalf("got %d failures, want %d", len(report.Failures), len(tt.wantNames))
+			}
+			for i, name := range tt.wantNames {
+				if report.Failures[i].Name != name {
+					t.Errorf("failure[%d].Name = %q, want %q", i, report.Failures[i].Name, name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Package sandbox executes a code artifact's test suite inside an
+// ephemeral, resource-limited Docker container and parses the test
+// runner's output into a structured TestReport, replacing the
+// TesterAgent's old rand.Float32() < 0.66 coin flip.
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/catalyst/backend/internal/config"
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	langGo     = "go"
+	langPython = "python"
+	langNode   = "node"
+
+	defaultTimeout = 2 * time.Minute
+)
+
+// image and testCmd are keyed by the detected language.
+var (
+	image = map[string]string{
+		langGo:     "golang:1.21",
+		langPython: "python:3.11-slim",
+		langNode:   "node:20-slim",
+	}
+	testCmd = map[string][]string{
+		langGo:     {"go", "test", "./...", "-v"},
+		langPython: {"pytest", "-v"},
+		langNode:   {"npm", "test", "--silent"},
+	}
+)
+
+// Runner executes a CoderAgent artifact's tests. DryRun keeps the legacy
+// simulated pass rate for environments without Docker (CI containers,
+// local dev, the sandbox this pipeline itself runs in).
+type Runner struct {
+	DryRun  bool
+	Timeout time.Duration
+}
+
+// NewRunner builds a Runner from cfg. DryRun is forced on unless a `docker`
+// binary is on PATH, so a missing `docker` binary degrades gracefully
+// instead of failing every task. Whether the Docker daemon is reachable is
+// not checked.
+func NewRunner(cfg *config.Config) *Runner {
+	dryRun := cfg.SandboxDryRun
+	if !dryRun {
+		if _, err := exec.LookPath("docker"); err != nil {
+			logger.Warn("sandbox: docker not found on PATH, falling back to dry-run test execution")
+			dryRun = true
+		}
+	}
+
+	timeout := cfg.SandboxTimeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Runner{DryRun: dryRun, Timeout: timeout}
+}
+
+// Run materializes files in a temp dir and runs that language's test
+// command inside a throwaway Docker container with no network access and
+// capped CPU/memory, then parses the container's output into a
+// TestReport. In DryRun mode it skips Docker entirely and returns a
+// simulated report.
+func (r *Runner) Run(ctx context.Context, files map[string][]byte) (*TestReport, error) {
+	if r.DryRun {
+		return dryRunReport(), nil
+	}
+
+	lang := detectLanguage(files)
+	if lang == "" {
+		logger.Warn("sandbox: could not detect a language from the artifact, skipping execution")
+		return &TestReport{}, nil
+	}
+
+	dir, err := Materialize(files)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	runCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	output, _ := r.runContainer(runCtx, dir, lang)
+	return parseOutput(lang, output), nil
+}
+
+// detectLanguage picks a language from the manifest files a project's
+// source tree usually carries, preferring the most specific signal.
+func detectLanguage(files map[string][]byte) string {
+	found := make(map[string]bool)
+	for path := range files {
+		switch filepath.Base(path) {
+		case "go.mod":
+			found[langGo] = true
+		case "package.json":
+			found[langNode] = true
+		case "requirements.txt", "pyproject.toml":
+			found[langPython] = true
+		}
+	}
+	for _, lang := range []string{langGo, langNode, langPython} {
+		if found[lang] {
+			return lang
+		}
+	}
+	return ""
+}
+
+// Materialize writes files into a fresh temp dir, rejecting any path that
+// would escape it. Callers are responsible for os.RemoveAll-ing the
+// returned dir once done with it.
+func Materialize(files map[string][]byte) (string, error) {
+	dir, err := os.MkdirTemp("", "catalyst-sandbox-*")
+	if err != nil {
+		return "", err
+	}
+
+	for path, content := range files {
+		clean := filepath.Clean(path)
+		if strings.HasPrefix(clean, "..") || filepath.IsAbs(clean) {
+			continue
+		}
+
+		full := filepath.Join(dir, clean)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+		if err := os.WriteFile(full, content, 0o644); err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+	}
+
+	return dir, nil
+}
+
+// runContainer runs lang's test command for dir inside an ephemeral
+// container with no network access and capped CPU/memory, returning
+// combined stdout+stderr. A non-zero exit code (including a timeout) is
+// expected for a failing suite, so it is not surfaced as an error — only
+// the combined output matters, since parseOutput derives pass/fail counts
+// from it directly.
+func (r *Runner) runContainer(ctx context.Context, dir, lang string) (string, error) {
+	args := []string{
+		"run", "--rm",
+		"--network=none",
+		"--cpus=1",
+		"--memory=512m",
+		"-v", dir + ":/workspace",
+		"-w", "/workspace",
+		image[lang],
+	}
+	args = append(args, testCmd[lang]...)
+
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return out.String(), err
+	}
+	return out.String(), nil
+}
+
+// dryRunReport reproduces the pre-sandbox behavior: a single simulated
+// test case that passes 66% of the time.
+func dryRunReport() *TestReport {
+	rand.Seed(time.Now().UnixNano())
+	if rand.Float32() < 0.66 {
+		return &TestReport{Passed: 1, Total: 1}
+	}
+	return &TestReport{
+		Failed: 1,
+		Total:  1,
+		Failures: []Failure{{
+			Name:    "simulated",
+			Message: "dry-run sandbox: simulated failure (no Docker available)",
+		}},
+	}
+}
@@ -0,0 +1,85 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ConfluenceProvider lists a Confluence Cloud space's pages over the REST
+// API and normalizes each into a SystemArtifact.
+type ConfluenceProvider struct {
+	baseURL  string
+	spaceKey string
+	token    string
+}
+
+// NewConfluenceProvider targets baseURL's spaceKey with token as the REST
+// API token.
+func NewConfluenceProvider(baseURL, spaceKey, token string) *ConfluenceProvider {
+	return &ConfluenceProvider{baseURL: strings.TrimRight(baseURL, "/"), spaceKey: spaceKey, token: token}
+}
+
+func (p *ConfluenceProvider) Name() string { return "confluence" }
+
+func (p *ConfluenceProvider) Fetch(ctx context.Context) ([]SystemArtifact, error) {
+	url := fmt.Sprintf("%s/wiki/rest/api/content?spaceKey=%s&expand=body.storage&limit=50", p.baseURL, p.spaceKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("confluence list for space %s: %w", p.spaceKey, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("confluence list for space %s: status %d", p.spaceKey, resp.StatusCode)
+	}
+
+	var result struct {
+		Results []struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+			Links struct {
+				WebUI string `json:"webui"`
+			} `json:"_links"`
+			Body struct {
+				Storage struct {
+					Value string `json:"value"`
+				} `json:"storage"`
+			} `json:"body"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	artifacts := make([]SystemArtifact, 0, len(result.Results))
+	for _, page := range result.Results {
+		artifacts = append(artifacts, SystemArtifact{
+			Source:  p.Name(),
+			Ref:     page.ID,
+			Title:   page.Title,
+			Content: truncate(stripHTML(page.Body.Storage.Value)),
+			URL:     p.baseURL + page.Links.WebUI,
+		})
+	}
+	return artifacts, nil
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// stripHTML does a best-effort plain-text extraction from Confluence's
+// storage-format XHTML so page bodies read naturally as LLM context.
+func stripHTML(storage string) string {
+	return strings.TrimSpace(htmlTag.ReplaceAllString(storage, " "))
+}
@@ -0,0 +1,113 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// interestingFiles are the files GitProvider extracts as artifacts: READMEs
+// for a human-readable overview, plus manifests/dependency files that hint
+// at tech stack and third-party exposure.
+var interestingFiles = []string{
+	"README.md", "README", "readme.md",
+	"go.mod", "package.json", "requirements.txt", "Pipfile",
+	"pom.xml", "build.gradle", "Gemfile", "Dockerfile",
+}
+
+// GitProvider clones a repository shallowly into memory and extracts its
+// README and manifest/dependency files as artifacts — enough to brief an
+// LLM on a system's tech stack without checking the whole tree out to disk.
+type GitProvider struct {
+	repoURL string
+	pat     string
+}
+
+// NewGitProvider builds a GitProvider for repoURL, authenticating clones
+// with pat (an empty pat clones anonymously, fine for public repos).
+func NewGitProvider(repoURL, pat string) *GitProvider {
+	return &GitProvider{repoURL: repoURL, pat: pat}
+}
+
+func (p *GitProvider) Name() string { return "git" }
+
+func (p *GitProvider) Fetch(ctx context.Context) ([]SystemArtifact, error) {
+	opts := &git.CloneOptions{
+		URL:   p.repoURL,
+		Depth: 1,
+	}
+	if p.pat != "" {
+		opts.Auth = &http.BasicAuth{Username: "x-access-token", Password: p.pat}
+	}
+
+	fs := memfs.New()
+	repo, err := git.CloneContext(ctx, memory.NewStorage(), fs, opts)
+	if err != nil {
+		return nil, fmt.Errorf("git clone %s: %w", p.repoURL, err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []SystemArtifact
+	err = util.Walk(wt.Filesystem, "/", func(path string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil || info.IsDir() {
+			return nil
+		}
+		if !wanted(info.Name()) {
+			return nil
+		}
+		content, readErr := readFile(wt.Filesystem, path)
+		if readErr != nil {
+			return nil
+		}
+		artifacts = append(artifacts, SystemArtifact{
+			Source:  p.Name(),
+			Ref:     strings.TrimPrefix(path, "/"),
+			Title:   info.Name(),
+			Content: truncate(content),
+			URL:     p.repoURL,
+		})
+		return nil
+	})
+	if err != nil {
+		return artifacts, err
+	}
+
+	return artifacts, nil
+}
+
+func wanted(name string) bool {
+	for _, candidate := range interestingFiles {
+		if strings.EqualFold(filepath.Base(name), candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func readFile(fs billy.Filesystem, path string) (string, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
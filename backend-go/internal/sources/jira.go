@@ -0,0 +1,94 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// JiraProvider lists a Jira Cloud project's epics and issues over the REST
+// v3 search API and normalizes each into a SystemArtifact.
+type JiraProvider struct {
+	baseURL string
+	project string
+	token   string
+}
+
+// NewJiraProvider targets baseURL (e.g. "https://acme.atlassian.net")'s
+// project with token as the REST v3 API token.
+func NewJiraProvider(baseURL, project, token string) *JiraProvider {
+	return &JiraProvider{baseURL: strings.TrimRight(baseURL, "/"), project: project, token: token}
+}
+
+func (p *JiraProvider) Name() string { return "jira" }
+
+func (p *JiraProvider) Fetch(ctx context.Context) ([]SystemArtifact, error) {
+	jql := fmt.Sprintf("project=%q ORDER BY created DESC", p.project)
+	reqURL := fmt.Sprintf("%s/rest/api/3/search?jql=%s&maxResults=50&fields=summary,description,issuetype", p.baseURL, url.QueryEscape(jql))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jira search for project %s: %w", p.project, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jira search for project %s: status %d", p.project, resp.StatusCode)
+	}
+
+	var result struct {
+		Issues []struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Summary     string      `json:"summary"`
+				Description interface{} `json:"description"`
+				IssueType   struct {
+					Name string `json:"name"`
+				} `json:"issuetype"`
+			} `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	artifacts := make([]SystemArtifact, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		artifacts = append(artifacts, SystemArtifact{
+			Source:  p.Name(),
+			Ref:     issue.Key,
+			Title:   fmt.Sprintf("[%s] %s", issue.Fields.IssueType.Name, issue.Fields.Summary),
+			Content: truncate(descriptionText(issue.Fields.Description)),
+			URL:     fmt.Sprintf("%s/browse/%s", p.baseURL, issue.Key),
+		})
+	}
+	return artifacts, nil
+}
+
+// descriptionText best-efforts a plain string out of a Jira description,
+// which the v3 API returns as Atlassian Document Format (nested JSON) rather
+// than plain text; falling back to a JSON dump keeps at least the raw
+// content usable as LLM context even without full ADF parsing.
+func descriptionText(desc interface{}) string {
+	if s, ok := desc.(string); ok {
+		return s
+	}
+	if desc == nil {
+		return ""
+	}
+	raw, err := json.Marshal(desc)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
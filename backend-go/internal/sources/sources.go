@@ -0,0 +1,43 @@
+// Package sources abstracts the read-only connectors ExplorerAgent fans out
+// across when it scans a system: a Git repository, a Jira project, a
+// Confluence space. Each SourceProvider normalizes whatever it finds into
+// SystemArtifacts so the agent can hand a uniform bundle to the LLM and
+// persist references to where every fact came from.
+package sources
+
+import "context"
+
+// SystemArtifact is one normalized unit of evidence pulled from a source:
+// a README, a Jira issue, a Confluence page. Ref is a stable identifier
+// (file path, issue key, page ID) downstream agents can cite back to.
+type SystemArtifact struct {
+	Source  string `json:"source" bson:"source"` // "git", "jira", "confluence"
+	Ref     string `json:"ref" bson:"ref"`
+	Title   string `json:"title" bson:"title"`
+	Content string `json:"content" bson:"content"`
+	URL     string `json:"url" bson:"url"`
+}
+
+// maxArtifactBytes caps a single artifact's content so one huge file or
+// issue description can't dominate the LLM prompt built from a scan.
+const maxArtifactBytes = 8 << 10 // 8 KiB
+
+// SourceProvider fetches and normalizes whatever a single connector can see
+// about a system. Implementations are read-only: Explorer never writes back
+// to Git, Jira, or Confluence.
+type SourceProvider interface {
+	// Name identifies the provider for logging and SystemArtifact.Source.
+	Name() string
+	// Fetch returns the provider's artifacts for this scan. A provider
+	// should return a partial result with an error rather than nothing, so
+	// one failing connector doesn't blank out artifacts other providers
+	// already gathered.
+	Fetch(ctx context.Context) ([]SystemArtifact, error)
+}
+
+func truncate(s string) string {
+	if len(s) <= maxArtifactBytes {
+		return s
+	}
+	return s[:maxArtifactBytes] + "\n... (truncated)"
+}
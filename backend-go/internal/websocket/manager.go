@@ -1,23 +1,100 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"sync"
+	"time"
 
+	"github.com/catalyst/backend/internal/bus"
+	"github.com/catalyst/backend/internal/database"
 	"github.com/gorilla/websocket"
 	logger "github.com/sirupsen/logrus"
 )
 
+// sendBufferSize bounds how many outbound frames can be queued for a slow
+// client before SendLog starts coalescing instead of blocking the caller.
+const sendBufferSize = 32
+
+// controlFrame is an inbound client message controlling a running task.
+// Anything else (e.g. ping/pong) is read and discarded.
+type controlFrame struct {
+	Action string `json:"action"`
+}
+
+// conn pairs a WebSocket connection with its outbound queue: SendLog
+// enqueues frames onto send and a dedicated pump goroutine writes them to
+// the socket, so one slow reader can't make SendLog block whichever agent
+// goroutine is logging.
+type conn struct {
+	ws   *websocket.Conn
+	send chan []byte
+	done chan struct{}
+}
+
+func newConn(ws *websocket.Conn) *conn {
+	c := &conn{ws: ws, send: make(chan []byte, sendBufferSize), done: make(chan struct{})}
+	go c.pump()
+	return c
+}
+
+func (c *conn) pump() {
+	for {
+		select {
+		case data := <-c.send:
+			if err := c.ws.WriteMessage(websocket.TextMessage, data); err != nil {
+				logger.Errorf("Failed to send log: %v", err)
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// enqueue queues data for the pump without ever blocking the caller. If
+// send is full, the oldest queued frame is dropped to make room — for a
+// stream of log lines the newest state matters more than catching up on
+// every stale one.
+func (c *conn) enqueue(data []byte) {
+	select {
+	case c.send <- data:
+		return
+	default:
+	}
+
+	select {
+	case <-c.send:
+	default:
+	}
+	select {
+	case c.send <- data:
+	default:
+	}
+}
+
+func (c *conn) close() {
+	close(c.done)
+	c.ws.Close()
+}
+
 type Manager struct {
-	connections map[string]*websocket.Conn
+	connections map[string][]*conn
 	mu          sync.RWMutex
 	upgrader    websocket.Upgrader
+
+	// Bus receives every decoded control frame (cancel/pause/resume) for
+	// subscribers in this same process. A queue.Worker in the cmd/worker
+	// process does not read it; it gets the frames through
+	// bus.PublishRemote and bus.Relay instead.
+	Bus *bus.Bus
 }
 
 func NewManager() *Manager {
 	return &Manager{
-		connections: make(map[string]*websocket.Conn),
+		connections: make(map[string][]*conn),
+		Bus:         bus.NewBus(),
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				return true // Allow all origins (configure for production)
@@ -26,39 +103,71 @@ func NewManager() *Manager {
 	}
 }
 
-func (m *Manager) HandleWebSocket(w http.ResponseWriter, r *http.Request, taskID string) {
-	conn, err := m.upgrader.Upgrade(w, r, nil)
+// HandleWebSocket registers conn as a subscriber for taskID — there can be
+// more than one at a time, e.g. an operator dashboard watching alongside the
+// client that started the task — and decodes inbound JSON control frames,
+// durably publishing each via bus.PublishRemote so the cmd/worker process
+// actually running taskID's pipeline picks it up through bus.Relay.
+func (m *Manager) HandleWebSocket(w http.ResponseWriter, r *http.Request, db *database.Database, taskID string) {
+	ws, err := m.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		logger.Errorf("Failed to upgrade connection: %v", err)
 		return
 	}
 
+	c := newConn(ws)
+
 	m.mu.Lock()
-	m.connections[taskID] = conn
+	m.connections[taskID] = append(m.connections[taskID], c)
 	m.mu.Unlock()
 
 	logger.Infof("WebSocket connected for task: %s", taskID)
 
-	// Read messages (ping/pong)
 	for {
-		_, _, err := conn.ReadMessage()
+		_, data, err := ws.ReadMessage()
 		if err != nil {
-			m.mu.Lock()
-			delete(m.connections, taskID)
-			m.mu.Unlock()
-			conn.Close()
+			m.removeConn(taskID, c)
 			logger.Infof("WebSocket disconnected for task: %s", taskID)
 			break
 		}
+
+		var frame controlFrame
+		if err := json.Unmarshal(data, &frame); err != nil || frame.Action == "" {
+			continue
+		}
+		m.Bus.Publish(taskID, bus.Event{Action: frame.Action})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := bus.PublishRemote(ctx, db, taskID, frame.Action); err != nil {
+			logger.Errorf("Failed to publish control event for task %s: %v", taskID, err)
+		}
+		cancel()
 	}
 }
 
+func (m *Manager) removeConn(taskID string, target *conn) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	conns := m.connections[taskID]
+	for i, c := range conns {
+		if c == target {
+			m.connections[taskID] = append(conns[:i], conns[i+1:]...)
+			break
+		}
+	}
+	if len(m.connections[taskID]) == 0 {
+		delete(m.connections, taskID)
+	}
+	target.close()
+}
+
 func (m *Manager) SendLog(taskID string, logData map[string]interface{}) {
 	m.mu.RLock()
-	conn, exists := m.connections[taskID]
+	conns := append([]*conn(nil), m.connections[taskID]...)
 	m.mu.RUnlock()
 
-	if !exists {
+	if len(conns) == 0 {
 		return
 	}
 
@@ -68,10 +177,7 @@ func (m *Manager) SendLog(taskID string, logData map[string]interface{}) {
 		return
 	}
 
-	if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
-		logger.Errorf("Failed to send log: %v", err)
-		m.mu.Lock()
-		delete(m.connections, taskID)
-		m.mu.Unlock()
+	for _, c := range conns {
+		c.enqueue(data)
 	}
-}
\ No newline at end of file
+}